@@ -0,0 +1,139 @@
+package cel
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultProgramCacheSize is the default number of compiled programs a
+// ProgramCache holds.
+const DefaultProgramCacheSize = 1024
+
+// A programKey identifies a compiled program by the environment it was
+// compiled in and its expression text.
+type programKey struct {
+	env  string
+	expr string
+}
+
+type programEntry struct {
+	key     programKey
+	program cel.Program
+}
+
+// A ProgramCache is a bounded cache of compiled CEL programs. When it's full
+// the least recently used program is evicted. A ProgramCache is safe for
+// concurrent use, and is a prometheus.Collector of its hit, miss and eviction
+// counts. A nil ProgramCache caches nothing.
+type ProgramCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[programKey]*list.Element
+
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions prometheus.Counter
+}
+
+// NewProgramCache returns a ProgramCache that holds up to size programs.
+func NewProgramCache(size int) *ProgramCache {
+	if size < 1 {
+		size = DefaultProgramCacheSize
+	}
+	return &ProgramCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[programKey]*list.Element, size),
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "function_auto_ready",
+			Subsystem: "cel_program_cache",
+			Name:      "hits_total",
+			Help:      "Number of CEL program lookups served from the cache.",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "function_auto_ready",
+			Subsystem: "cel_program_cache",
+			Name:      "misses_total",
+			Help:      "Number of CEL program lookups that required compiling the expression.",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "function_auto_ready",
+			Subsystem: "cel_program_cache",
+			Name:      "evictions_total",
+			Help:      "Number of compiled CEL programs evicted from the cache.",
+		}),
+	}
+}
+
+// Get returns the program compiled from the supplied expression in the
+// supplied environment, if it's cached.
+func (c *ProgramCache) Get(env, expr string) (cel.Program, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[programKey{env: env, expr: expr}]
+	if !ok {
+		c.misses.Inc()
+		return nil, false
+	}
+	c.hits.Inc()
+	c.order.MoveToFront(e)
+	return e.Value.(*programEntry).program, true
+}
+
+// Add caches the program compiled from the supplied expression in the
+// supplied environment, evicting the least recently used program if the cache
+// is full.
+func (c *ProgramCache) Add(env, expr string, p cel.Program) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := programKey{env: env, expr: expr}
+	if e, ok := c.entries[k]; ok {
+		e.Value.(*programEntry).program = p
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[k] = c.order.PushFront(&programEntry{key: k, program: p})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*programEntry).key)
+		c.evictions.Inc()
+	}
+}
+
+// Len returns the number of cached programs.
+func (c *ProgramCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Describe implements prometheus.Collector.
+func (c *ProgramCache) Describe(ch chan<- *prometheus.Desc) {
+	c.hits.Describe(ch)
+	c.misses.Describe(ch)
+	c.evictions.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *ProgramCache) Collect(ch chan<- prometheus.Metric) {
+	c.hits.Collect(ch)
+	c.misses.Collect(ch)
+	c.evictions.Collect(ch)
+}
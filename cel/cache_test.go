@@ -0,0 +1,91 @@
+package cel
+
+import (
+	"testing"
+
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestProgramCache(t *testing.T) {
+	type want struct {
+		ready     []resource.Ready
+		cached    int
+		hits      float64
+		misses    float64
+		evictions float64
+	}
+
+	cases := map[string]struct {
+		reason string
+		size   int
+		exprs  []string
+		want   want
+	}{
+		"RepeatedExpression": {
+			reason: "Evaluating the same expression again should use the cached program",
+			size:   2,
+			exprs:  []string{"object.ready == true", "object.ready == true", "object.ready == true"},
+			want: want{
+				ready:  []resource.Ready{resource.ReadyTrue, resource.ReadyTrue, resource.ReadyTrue},
+				cached: 1,
+				hits:   2,
+				misses: 1,
+			},
+		},
+		"EvictLeastRecentlyUsed": {
+			reason: "The least recently used program should be evicted when the cache is full",
+			size:   2,
+			exprs:  []string{"object.ready == true", "object.ready == false", "object.ready == true", "object.count > 1", "object.ready == false"},
+			want: want{
+				ready:     []resource.Ready{resource.ReadyTrue, resource.ReadyFalse, resource.ReadyTrue, resource.ReadyTrue, resource.ReadyFalse},
+				cached:    2,
+				hits:      1,
+				misses:    4,
+				evictions: 2,
+			},
+		},
+		"InvalidExpression": {
+			reason: "Expressions that fail to compile should not be cached",
+			size:   2,
+			exprs:  []string{"object.count", "object.count"},
+			want: want{
+				ready:  []resource.Ready{resource.ReadyUnspecified, resource.ReadyUnspecified},
+				cached: 0,
+				misses: 2,
+			},
+		},
+	}
+
+	obj := map[string]any{"ready": true, "count": 2}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewProgramCache(tc.size)
+			r := Resolver{Programs: c}
+
+			got := make([]resource.Ready, 0, len(tc.exprs))
+			for _, expr := range tc.exprs {
+				ready, _ := r.HealthDeriveFromCelQuery(expr, obj, nil)
+				got = append(got, ready)
+			}
+
+			if diff := cmp.Diff(tc.want.ready, got); diff != "" {
+				t.Errorf("%s\nr.HealthDeriveFromCelQuery(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cached, c.Len()); diff != "" {
+				t.Errorf("%s\nc.Len(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hits, testutil.ToFloat64(c.hits)); diff != "" {
+				t.Errorf("%s\nhits: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.misses, testutil.ToFloat64(c.misses)); diff != "" {
+				t.Errorf("%s\nmisses: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.evictions, testutil.ToFloat64(c.evictions)); diff != "" {
+				t.Errorf("%s\nevictions: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
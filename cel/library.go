@@ -0,0 +1,201 @@
+package cel
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/function-auto-ready/nested"
+)
+
+// Kubernetes returns a CEL library of helper functions for Kubernetes
+// resources:
+//
+//	hasCondition(object, type) bool
+//	hasCondition(object, type, status) bool
+//	conditionStatus(object, type) string
+//	conditionReason(object, type) string
+//	conditionAge(object, type) duration
+//	observedGenerationCurrent(object) bool
+//	compareQuantity(a, b) int
+//	age(timestamp) duration
+//
+// The age helpers measure from the time returned by now.
+func Kubernetes(now func() time.Time) cel.EnvOption {
+	return cel.Lib(kubernetesLib{now: now})
+}
+
+type kubernetesLib struct {
+	now func() time.Time
+}
+
+func (l kubernetesLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("hasCondition",
+			cel.Overload("hasCondition_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(obj, t ref.Val) ref.Val {
+					c, err := condition(obj, t)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					return celtypes.Bool(c != nil && c["status"] == "True")
+				})),
+			cel.Overload("hasCondition_dyn_string_string", []*cel.Type{cel.DynType, cel.StringType, cel.StringType}, cel.BoolType,
+				cel.FunctionBinding(func(args ...ref.Val) ref.Val {
+					c, err := condition(args[0], args[1])
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					return celtypes.Bool(c != nil && c["status"] == args[2].Value())
+				})),
+		),
+		cel.Function("conditionStatus",
+			cel.Overload("conditionStatus_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(obj, t ref.Val) ref.Val {
+					c, err := condition(obj, t)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					if s, ok := c["status"].(string); ok {
+						return celtypes.String(s)
+					}
+					return celtypes.String(metav1.ConditionUnknown)
+				})),
+		),
+		cel.Function("conditionReason",
+			cel.Overload("conditionReason_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(obj, t ref.Val) ref.Val {
+					c, err := condition(obj, t)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					r, _ := c["reason"].(string)
+					return celtypes.String(r)
+				})),
+		),
+		cel.Function("conditionAge",
+			cel.Overload("conditionAge_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.DurationType,
+				cel.BinaryBinding(func(obj, t ref.Val) ref.Val {
+					c, err := condition(obj, t)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					if c == nil {
+						return celtypes.NewErr("object has no %s condition", t.Value())
+					}
+					return age(l.now(), c["lastTransitionTime"])
+				})),
+		),
+		cel.Function("observedGenerationCurrent",
+			cel.Overload("observedGenerationCurrent_dyn", []*cel.Type{cel.DynType}, cel.BoolType,
+				cel.UnaryBinding(func(obj ref.Val) ref.Val {
+					u, err := toObject(obj)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					generation, found := nested.Int64(u, "metadata", "generation")
+					if !found {
+						return celtypes.True
+					}
+					observed, found := nested.Int64(u, "status", "observedGeneration")
+					return celtypes.Bool(found && observed >= generation)
+				})),
+		),
+		cel.Function("compareQuantity",
+			cel.Overload("compareQuantity_dyn_dyn", []*cel.Type{cel.DynType, cel.DynType}, cel.IntType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					qa, err := quantity(a)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					qb, err := quantity(b)
+					if err != nil {
+						return celtypes.WrapErr(err)
+					}
+					return celtypes.Int(qa.Cmp(qb))
+				})),
+		),
+		cel.Function("age",
+			cel.Overload("age_dyn", []*cel.Type{cel.DynType}, cel.DurationType,
+				cel.UnaryBinding(func(ts ref.Val) ref.Val {
+					return age(l.now(), ts.Value())
+				})),
+		),
+	}
+}
+
+func (kubernetesLib) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// toObject converts a CEL value to an unstructured Kubernetes object.
+func toObject(v ref.Val) (map[string]any, error) {
+	native, err := v.ConvertToNative(reflect.TypeFor[map[string]any]())
+	if err != nil {
+		return nil, fmt.Errorf("expected an object: %w", err)
+	}
+	m, ok := native.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", native)
+	}
+	return m, nil
+}
+
+// condition returns the status condition of the supplied type, or nil if the
+// object has no such condition.
+func condition(obj, t ref.Val) (map[string]any, error) {
+	u, err := toObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	conditions, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	for _, c := range conditions {
+		m, ok := c.(map[string]any)
+		if ok && m["type"] == t.Value() {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// quantity parses a Kubernetes quantity, e.g. "500Mi", from a string or a
+// number.
+func quantity(v ref.Val) (apiresource.Quantity, error) {
+	switch q := v.Value().(type) {
+	case string:
+		return apiresource.ParseQuantity(q)
+	case int64:
+		return *apiresource.NewQuantity(q, apiresource.DecimalSI), nil
+	case uint64:
+		return *apiresource.NewQuantity(int64(q), apiresource.DecimalSI), nil
+	case float64:
+		return apiresource.ParseQuantity(fmt.Sprintf("%g", q))
+	default:
+		return apiresource.Quantity{}, fmt.Errorf("cannot parse %v as a quantity", v.Value())
+	}
+}
+
+// age returns how long before now the supplied RFC 3339 timestamp was.
+func age(now time.Time, v any) ref.Val {
+	var t time.Time
+	switch ts := v.(type) {
+	case string:
+		parsed, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return celtypes.WrapErr(fmt.Errorf("cannot parse %q as a timestamp: %w", ts, err))
+		}
+		t = parsed
+	case time.Time:
+		t = ts
+	default:
+		return celtypes.NewErr("cannot parse %v as a timestamp", v)
+	}
+	return celtypes.Duration{Duration: now.Sub(t)}
+}
@@ -0,0 +1,267 @@
+package cel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// now is the current time according to the environment library tests
+// evaluate expressions in.
+var now = time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+// evaluate compiles and evaluates the supplied expression against the
+// supplied object, returning its native value.
+func evaluate(expr string, obj map[string]any) (any, error) {
+	env, err := newHealthCheckEnv(func() time.Time { return now })
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	p, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	v, err := Resolver{}.eval(p, obj, nil)
+	if err != nil {
+		return nil, err
+	}
+	return v.Value(), nil
+}
+
+type libraryCase struct {
+	reason string
+	expr   string
+	obj    map[string]any
+	want   any
+	err    bool
+}
+
+func runLibraryCases(t *testing.T, cases map[string]libraryCase) {
+	t.Helper()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := evaluate(tc.expr, tc.obj)
+			if (err != nil) != tc.err {
+				t.Fatalf("%s\nevaluate(%q): want error %t, got %v", tc.reason, tc.expr, tc.err, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("%s\nevaluate(%q): -want, +got:\n%s", tc.reason, tc.expr, diff)
+			}
+		})
+	}
+}
+
+var withConditions = map[string]any{
+	"status": map[string]any{
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": "2026-10-18T09:30:00Z"},
+			map[string]any{"type": "Synced", "status": "False", "reason": "ReconcileError"},
+		},
+	},
+}
+
+func TestHasCondition(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"True": {
+			reason: "A condition with status True should be reported",
+			expr:   `hasCondition(object, 'Ready')`,
+			obj:    withConditions,
+			want:   true,
+		},
+		"False": {
+			reason: "A condition with status False should not be reported",
+			expr:   `hasCondition(object, 'Synced')`,
+			obj:    withConditions,
+			want:   false,
+		},
+		"Missing": {
+			reason: "A missing condition should not be reported",
+			expr:   `hasCondition(object, 'Healthy')`,
+			obj:    withConditions,
+			want:   false,
+		},
+		"WithStatus": {
+			reason: "A condition with the supplied status should be reported",
+			expr:   `hasCondition(object, 'Synced', 'False')`,
+			obj:    withConditions,
+			want:   true,
+		},
+		"NotAnObject": {
+			reason: "Passing something other than an object should return an error",
+			expr:   `hasCondition('Ready', 'Ready')`,
+			err:    true,
+		},
+	})
+}
+
+func TestConditionStatus(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Present": {
+			reason: "The status of a present condition should be returned",
+			expr:   `conditionStatus(object, 'Synced')`,
+			obj:    withConditions,
+			want:   "False",
+		},
+		"Missing": {
+			reason: "A missing condition should have status Unknown",
+			expr:   `conditionStatus(object, 'Healthy')`,
+			obj:    withConditions,
+			want:   "Unknown",
+		},
+	})
+}
+
+func TestConditionReason(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Present": {
+			reason: "The reason of a present condition should be returned",
+			expr:   `conditionReason(object, 'Synced')`,
+			obj:    withConditions,
+			want:   "ReconcileError",
+		},
+		"Missing": {
+			reason: "A missing condition should have an empty reason",
+			expr:   `conditionReason(object, 'Healthy')`,
+			obj:    withConditions,
+			want:   "",
+		},
+	})
+}
+
+func TestConditionAge(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Present": {
+			reason: "The time since a condition last transitioned should be returned",
+			expr:   `conditionAge(object, 'Ready')`,
+			obj:    withConditions,
+			want:   30 * time.Minute,
+		},
+		"Compare": {
+			reason: "Condition ages should be comparable with durations",
+			expr:   `conditionAge(object, 'Ready') > duration('10m')`,
+			obj:    withConditions,
+			want:   true,
+		},
+		"Missing": {
+			reason: "A missing condition should return an error",
+			expr:   `conditionAge(object, 'Healthy')`,
+			obj:    withConditions,
+			err:    true,
+		},
+		"NoTransitionTime": {
+			reason: "A condition without a last transition time should return an error",
+			expr:   `conditionAge(object, 'Synced')`,
+			obj:    withConditions,
+			err:    true,
+		},
+	})
+}
+
+func TestObservedGenerationCurrent(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Current": {
+			reason: "An object whose status observed the current generation should be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj: map[string]any{
+				"metadata": map[string]any{"generation": int64(3)},
+				"status":   map[string]any{"observedGeneration": int64(3)},
+			},
+			want: true,
+		},
+		"Stale": {
+			reason: "An object whose status observed an older generation should not be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj: map[string]any{
+				"metadata": map[string]any{"generation": int64(3)},
+				"status":   map[string]any{"observedGeneration": int64(2)},
+			},
+			want: false,
+		},
+		"NotObserved": {
+			reason: "An object whose status doesn't report an observed generation should not be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj:    map[string]any{"metadata": map[string]any{"generation": int64(1)}},
+			want:   false,
+		},
+		"CurrentFloat": {
+			reason: "Numbers decoded from JSON are float64, and an object whose status observed the current generation should still be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj: map[string]any{
+				"metadata": map[string]any{"generation": float64(3)},
+				"status":   map[string]any{"observedGeneration": float64(3)},
+			},
+			want: true,
+		},
+		"StaleFloat": {
+			reason: "Numbers decoded from JSON are float64, and an object whose status observed an older generation should still not be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj: map[string]any{
+				"metadata": map[string]any{"generation": float64(3)},
+				"status":   map[string]any{"observedGeneration": float64(2)},
+			},
+			want: false,
+		},
+		"NoGeneration": {
+			reason: "An object without a generation should be current",
+			expr:   `observedGenerationCurrent(object)`,
+			obj:    map[string]any{},
+			want:   true,
+		},
+	})
+}
+
+func TestCompareQuantity(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Less": {
+			reason: "Quantities in different units should be compared by value",
+			expr:   `compareQuantity('500Mi', '1Gi')`,
+			want:   int64(-1),
+		},
+		"Equal": {
+			reason: "Equal quantities in different units should compare equal",
+			expr:   `compareQuantity('1000m', 1)`,
+			want:   int64(0),
+		},
+		"Field": {
+			reason: "Quantities should be read from object fields",
+			expr:   `compareQuantity(object.status.capacity, object.spec.size) >= 0`,
+			obj: map[string]any{
+				"spec":   map[string]any{"size": "10Gi"},
+				"status": map[string]any{"capacity": "16Gi"},
+			},
+			want: true,
+		},
+		"Invalid": {
+			reason: "An invalid quantity should return an error",
+			expr:   `compareQuantity('lots', '1Gi')`,
+			err:    true,
+		},
+	})
+}
+
+func TestAge(t *testing.T) {
+	runLibraryCases(t, map[string]libraryCase{
+		"Timestamp": {
+			reason: "The time since an RFC 3339 timestamp should be returned",
+			expr:   `age(object.metadata.creationTimestamp)`,
+			obj:    map[string]any{"metadata": map[string]any{"creationTimestamp": "2026-10-18T08:00:00Z"}},
+			want:   2 * time.Hour,
+		},
+		"Compare": {
+			reason: "Ages should be comparable with durations",
+			expr:   `age(object.metadata.creationTimestamp) < duration('1h')`,
+			obj:    map[string]any{"metadata": map[string]any{"creationTimestamp": "2026-10-18T08:00:00Z"}},
+			want:   false,
+		},
+		"Invalid": {
+			reason: "An invalid timestamp should return an error",
+			expr:   `age('yesterday')`,
+			err:    true,
+		},
+	})
+}
@@ -1,65 +1,249 @@
 package cel
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
 	"github.com/crossplane/function-sdk-go/resource"
 	"github.com/google/cel-go/cel"
 	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
 )
 
 type Resolver struct {
-	HealthCheckRegistry map[string]string
+	// Rules are the health check rules. See RuleFor for how rules that match
+	// the same composed resource take precedence.
+	Rules []Rule
+
+	// Composite is the observed composite resource, available to expressions
+	// as composite.
+	Composite map[string]any
+
+	// Context is the function context, available to expressions as context.
+	Context map[string]any
+
+	// Resources are the observed composed resources keyed by composition
+	// resource name, available to expressions as resources.
+	Resources map[string]any
+
+	// Programs caches compiled health check programs across evaluations.
+	// Every evaluation compiles its expression when Programs is nil.
+	Programs *ProgramCache
+
+	// CostLimit limits the runtime cost of each evaluation. Zero means no
+	// limit.
+	CostLimit uint64
+
+	// Timeout limits how long each evaluation may run. Zero means no limit.
+	Timeout time.Duration
+
+	// Now returns the current time, which age helpers measure from. It
+	// defaults to time.Now. Programs aren't cached when it's set, because
+	// they're compiled in an environment of their own.
+	Now func() time.Time
 }
 
 const (
 	errCelQueryFailedToCompile           = "failed to compile query"
 	errCelQueryReturnTypeNotBool         = "celQuery does not return a bool type"
+	errCelQueryReturnTypeNotBoolOrMap    = "celQuery does not return a bool or map type"
+	errCelQueryReturnTypeNotString       = "celQuery does not return a string type"
+	errCelQueryInvalidHealthResult       = "celQuery did not return a valid health result"
+	errCelQueryCostLimitExceeded         = "celQuery exceeded its cost limit of %d"
+	errCelQueryTimeoutExceeded           = "celQuery exceeded its evaluation timeout of %s"
 	errCelQueryFailedToCreateProgram     = "failed to create program from the cel query"
 	errCelQueryFailedToEvalProgram       = "failed to eval the program"
 	errCelQueryFailedToCreateEnvironment = "cel query failed to create environment"
 )
 
-func (r Resolver) GetHealthCheck(gvk schema.GroupVersionKind) (celQuery string, found bool) {
-	gvkKey := gvk.Group + "_" + gvk.Version + "_" + gvk.Kind
+// envHealthCheck identifies the environment health check expressions are
+// compiled in. Change it whenever the environment's options change.
+const envHealthCheck = "healthcheck/v3"
 
-	celQuery, found = r.HealthCheckRegistry[gvkKey]
-	return
-}
+// An output is the kind of value an expression may return. Programs are cached
+// per output, because the output is checked when the expression is compiled.
+type output string
 
-func (r Resolver) HealthDeriveFromCelQuery(celQuery string, obj map[string]any) (ready resource.Ready, err error) {
-	ready = resource.ReadyUnspecified
+const (
+	// outputBool expressions return whether the object is ready.
+	outputBool output = "bool"
+	// outputHealth expressions return whether the object is healthy, or a
+	// map with a status and an optional message.
+	outputHealth output = "health"
+	// outputString expressions return a message.
+	outputString output = "string"
+)
+
+// interruptCheckFrequency is how many comprehension iterations run between
+// checks of whether an evaluation has timed out.
+const interruptCheckFrequency = 100
 
-	env, err := cel.NewEnv(
+// healthCheckEnv returns the environment health check expressions are
+// compiled in when they use the system clock. It's created once and shared by
+// all health checks.
+var healthCheckEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return newHealthCheckEnv(time.Now)
+})
+
+// newHealthCheckEnv returns an environment for health check expressions whose
+// age helpers measure from the time returned by now.
+func newHealthCheckEnv(now func() time.Time) (*cel.Env, error) {
+	return cel.NewEnv(
 		cel.Variable("object", cel.AnyType),
+		cel.Variable("desired", cel.AnyType),
+		cel.Variable("composite", cel.AnyType),
+		cel.Variable("context", cel.AnyType),
+		cel.Variable("resources", cel.AnyType),
+		Kubernetes(now),
 	)
+}
+
+// Validate compiles the expressions of every rule, returning an error keyed by
+// rule name for each rule with an expression that doesn't compile or doesn't
+// return the right type. Invalid rules are removed so they're never
+// evaluated. Compiled programs are cached, so evaluating a valid rule later
+// doesn't compile it again.
+func (r *Resolver) Validate() map[string]error {
+	invalid := make(map[string]error)
+	valid := make([]Rule, 0, len(r.Rules))
+	for _, rule := range r.Rules {
+		if _, err := r.program(rule.Expression, outputHealth); err != nil {
+			invalid[rule.Name] = err
+			continue
+		}
+		if rule.MessageExpression != "" {
+			if _, err := r.program(rule.MessageExpression, outputString); err != nil {
+				invalid[rule.Name] = errors.Wrap(err, "invalid messageExpression")
+				continue
+			}
+		}
+		valid = append(valid, rule)
+	}
+	r.Rules = valid
+	return invalid
+}
+
+// Evaluate evaluates the supplied rule against the supplied observed object
+// and its desired state.
+func (r Resolver) Evaluate(rule Rule, obj, desired map[string]any) (healthchecks.HealthStatus, error) {
+	h, err := r.HealthStatusFromCelQuery(rule.Expression, obj, desired)
+	if err != nil || rule.MessageExpression == "" {
+		return h, err
+	}
+
+	program, err := r.program(rule.MessageExpression, outputString)
 	if err != nil {
-		err = errors.Wrap(err, errCelQueryFailedToCreateEnvironment)
-		return ready, err
+		return healthchecks.HealthStatus{}, errors.Wrap(err, "invalid messageExpression")
 	}
+	val, err := r.eval(program, obj, desired)
+	if err != nil {
+		return healthchecks.HealthStatus{}, errors.Wrap(err, "cannot evaluate messageExpression")
+	}
+	msg, _ := val.Value().(string)
+	h.Message = msg
+	return h, nil
+}
 
-	ast, iss := env.Compile(celQuery)
+// program returns the program compiled from the supplied health check
+// expression, using a cached program if there is one.
+func (r Resolver) program(celQuery string, out output) (cel.Program, error) {
+	programs, env := r.Programs, healthCheckEnv
+	if r.Now != nil {
+		programs = nil
+		env = func() (*cel.Env, error) { return newHealthCheckEnv(r.Now) }
+	}
+
+	key := fmt.Sprintf("%s/%s/cost=%d", envHealthCheck, out, r.CostLimit)
+	if p, ok := programs.Get(key, celQuery); ok {
+		return p, nil
+	}
+
+	e, err := env()
+	if err != nil {
+		return nil, errors.Wrap(err, errCelQueryFailedToCreateEnvironment)
+	}
+
+	ast, iss := e.Compile(celQuery)
 	if iss.Err() != nil {
-		err = errors.Wrap(iss.Err(), errCelQueryFailedToCompile)
-		return ready, err
+		return nil, errors.Wrap(iss.Err(), errCelQueryFailedToCompile)
 	}
 
-	if !ast.OutputType().IsExactType(cel.BoolType) {
-		err = errors.New(errCelQueryReturnTypeNotBool)
-		return ready, err
+	switch t := ast.OutputType(); {
+	case out == outputString && t.IsExactType(cel.StringType):
+	case out == outputString:
+		return nil, errors.New(errCelQueryReturnTypeNotString)
+	case t.IsExactType(cel.BoolType):
+	case out == outputHealth && t.Kind() == celtypes.MapKind:
+	case out == outputHealth:
+		return nil, errors.New(errCelQueryReturnTypeNotBoolOrMap)
+	default:
+		return nil, errors.New(errCelQueryReturnTypeNotBool)
 	}
 
-	program, err := env.Program(ast)
+	opts := []cel.ProgramOption{cel.InterruptCheckFrequency(interruptCheckFrequency)}
+	if r.CostLimit > 0 {
+		opts = append(opts, cel.CostLimit(r.CostLimit))
+	}
+	p, err := e.Program(ast, opts...)
 	if err != nil {
-		err = errors.Wrap(err, errCelQueryFailedToCreateProgram)
-		return ready, err
+		return nil, errors.Wrap(err, errCelQueryFailedToCreateProgram)
 	}
 
-	val, _, err := program.Eval(map[string]any{
-		"object": obj,
+	programs.Add(key, celQuery, p)
+	return p, nil
+}
+
+// eval evaluates the supplied program against the supplied observed object
+// and its desired state, within the resolver's cost limit and timeout.
+func (r Resolver) eval(p cel.Program, obj, desired map[string]any) (ref.Val, error) {
+	ctx := context.Background()
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	val, _, err := p.ContextEval(ctx, map[string]any{
+		"object":    orEmpty(obj),
+		"desired":   orEmpty(desired),
+		"composite": orEmpty(r.Composite),
+		"context":   orEmpty(r.Context),
+		"resources": orEmpty(r.Resources),
 	})
+
+	var cancelled interpreter.EvalCancelledError
+	switch {
+	case err == nil:
+		return val, nil
+	case errors.As(err, &cancelled) && cancelled.Cause == interpreter.CostLimitExceeded:
+		return nil, errors.Errorf(errCelQueryCostLimitExceeded, r.CostLimit)
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, errors.Errorf(errCelQueryTimeoutExceeded, r.Timeout)
+	default:
+		return nil, errors.Wrap(err, errCelQueryFailedToEvalProgram)
+	}
+}
+
+// HealthDeriveFromCelQuery evaluates the supplied health check expression
+// against the supplied observed object and its desired state. The expression
+// must return a bool.
+func (r Resolver) HealthDeriveFromCelQuery(celQuery string, obj, desired map[string]any) (ready resource.Ready, err error) {
+	ready = resource.ReadyUnspecified
+
+	program, err := r.program(celQuery, outputBool)
+	if err != nil {
+		return ready, err
+	}
+
+	val, err := r.eval(program, obj, desired)
 	if err != nil {
-		err = errors.Wrap(err, errCelQueryFailedToEvalProgram)
 		return ready, err
 	}
 
@@ -70,3 +254,62 @@ func (r Resolver) HealthDeriveFromCelQuery(celQuery string, obj map[string]any)
 	}
 	return ready, err
 }
+
+// HealthStatusFromCelQuery evaluates the supplied health check expression
+// against the supplied observed object and its desired state. The expression
+// may return a bool, where false means the object is degraded, or a map with
+// a status and an optional message, e.g.
+//
+//	{"status": "Progressing", "message": "waiting for rollout"}
+func (r Resolver) HealthStatusFromCelQuery(celQuery string, obj, desired map[string]any) (healthchecks.HealthStatus, error) {
+	program, err := r.program(celQuery, outputHealth)
+	if err != nil {
+		return healthchecks.HealthStatus{}, err
+	}
+
+	val, err := r.eval(program, obj, desired)
+	if err != nil {
+		return healthchecks.HealthStatus{}, err
+	}
+
+	switch v := val.Value().(type) {
+	case bool:
+		if v {
+			return healthchecks.Healthy(""), nil
+		}
+		return healthchecks.Degraded("health check expression evaluated to false"), nil
+	default:
+		return healthResult(val)
+	}
+}
+
+// healthResult converts a map returned by a health check expression to a
+// health status.
+func healthResult(val ref.Val) (healthchecks.HealthStatus, error) {
+	native, err := val.ConvertToNative(reflect.TypeFor[map[string]any]())
+	if err != nil {
+		return healthchecks.HealthStatus{}, errors.Wrap(err, errCelQueryInvalidHealthResult)
+	}
+	m, ok := native.(map[string]any)
+	if !ok {
+		return healthchecks.HealthStatus{}, errors.New(errCelQueryInvalidHealthResult)
+	}
+
+	status, _ := m["status"].(string)
+	message, _ := m["message"].(string)
+	switch code := healthchecks.HealthStatusCode(status); code {
+	case healthchecks.HealthStatusHealthy, healthchecks.HealthStatusProgressing, healthchecks.HealthStatusDegraded, healthchecks.HealthStatusSuspended:
+		return healthchecks.HealthStatus{Status: code, Message: message}, nil
+	default:
+		return healthchecks.HealthStatus{}, errors.Errorf("%s: unknown status %q, must be one of Healthy, Progressing, Degraded or Suspended", errCelQueryInvalidHealthResult, status)
+	}
+}
+
+// orEmpty returns the supplied map, or an empty map if it's nil, so that
+// expressions can test for fields with has() on every variable.
+func orEmpty(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
@@ -0,0 +1,236 @@
+package cel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+func TestHealthDeriveFromCelQueryVariables(t *testing.T) {
+	r := Resolver{
+		Composite: map[string]any{
+			"spec": map[string]any{"parameters": map[string]any{"replicas": int64(3)}},
+		},
+		Context: map[string]any{
+			"apiextensions.crossplane.io/environment": map[string]any{"region": "eu-west-1"},
+		},
+		Resources: map[string]any{
+			"database": map[string]any{
+				"status": map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": "True"}}},
+			},
+		},
+	}
+	obj := map[string]any{"status": map[string]any{"replicas": int64(3), "region": "eu-west-1"}}
+	desired := map[string]any{"spec": map[string]any{"replicas": int64(3)}}
+
+	type want struct {
+		ready resource.Ready
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		query   string
+		desired map[string]any
+		want    want
+	}{
+		"Composite": {
+			reason: "Expressions should be able to refer to the observed composite resource",
+			query:  `object.status.replicas == composite.spec.parameters.replicas`,
+			want:   want{ready: resource.ReadyTrue},
+		},
+		"Desired": {
+			reason:  "Expressions should be able to refer to the desired composed resource",
+			query:   `object.status.replicas == desired.spec.replicas`,
+			desired: desired,
+			want:    want{ready: resource.ReadyTrue},
+		},
+		"DesiredUnset": {
+			reason: "Expressions should be able to test for fields of an unset desired composed resource",
+			query:  `has(desired.spec)`,
+			want:   want{ready: resource.ReadyFalse},
+		},
+		"Context": {
+			reason: "Expressions should be able to refer to the function context",
+			query:  `object.status.region == context["apiextensions.crossplane.io/environment"].region`,
+			want:   want{ready: resource.ReadyTrue},
+		},
+		"Resources": {
+			reason: "Expressions should be able to refer to observed composed resources by name",
+			query:  `resources.database.status.conditions.exists(c, c.type == 'Ready' && c.status == 'True')`,
+			want:   want{ready: resource.ReadyTrue},
+		},
+		"MissingResource": {
+			reason: "Referring to a composed resource that isn't observed should return an error",
+			query:  `resources.cache.status.ready == true`,
+			want:   want{ready: resource.ReadyUnspecified, err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ready, err := r.HealthDeriveFromCelQuery(tc.query, obj, tc.desired)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nr.HealthDeriveFromCelQuery(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("%s\nr.HealthDeriveFromCelQuery(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthStatusFromCelQuery(t *testing.T) {
+	obj := map[string]any{"status": map[string]any{"phase": "Failed", "reason": "ImagePullBackOff"}}
+
+	type want struct {
+		health healthchecks.HealthStatus
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		query  string
+		want   want
+	}{
+		"BoolTrue": {
+			reason: "An expression returning true should report the object healthy",
+			query:  `object.status.phase == 'Failed'`,
+			want:   want{health: healthchecks.Healthy("")},
+		},
+		"BoolFalse": {
+			reason: "An expression returning false should report the object degraded",
+			query:  `object.status.phase == 'Running'`,
+			want:   want{health: healthchecks.Degraded("health check expression evaluated to false")},
+		},
+		"Map": {
+			reason: "An expression returning a map should report its status and message",
+			query:  `object.status.phase == 'Failed' ? {'status': 'Degraded', 'message': object.status.reason} : {'status': 'Healthy'}`,
+			want:   want{health: healthchecks.Degraded("ImagePullBackOff")},
+		},
+		"MapWithoutMessage": {
+			reason: "The message of a returned map should be optional",
+			query:  `{'status': 'Suspended'}`,
+			want:   want{health: healthchecks.Suspended("")},
+		},
+		"UnknownStatus": {
+			reason: "An expression returning an unknown status should return an error",
+			query:  `{'status': 'Broken'}`,
+			want:   want{err: true},
+		},
+		"MissingStatus": {
+			reason: "An expression returning a map without a status should return an error",
+			query:  `{'message': 'hello'}`,
+			want:   want{err: true},
+		},
+		"WrongType": {
+			reason: "An expression returning neither a bool nor a map should return an error",
+			query:  `object.status.phase + '!'`,
+			want:   want{err: true},
+		},
+		"Age": {
+			reason: "Age helpers should measure from the resolver's clock",
+			query:  `age('2026-10-18T09:00:00Z') > duration('30m') ? {'status': 'Degraded', 'message': 'started an hour ago'} : {'status': 'Healthy'}`,
+			want:   want{health: healthchecks.Degraded("started an hour ago")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := Resolver{Now: func() time.Time { return now }}
+			h, err := r.HealthStatusFromCelQuery(tc.query, obj, nil)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nHealthStatusFromCelQuery(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.health, h); diff != "" {
+				t.Errorf("%s\nHealthStatusFromCelQuery(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r := Resolver{Rules: []Rule{
+		{Name: "deployment", Group: "apps", Version: "v1", Kind: "Deployment", Expression: `hasCondition(object, 'Available')`},
+		{Name: "configmap", Version: "v1", Kind: "ConfigMap", Expression: `{'status': 'Healthy'}`},
+		{Name: "configuration", Group: "pkg.crossplane.io", Version: "v1", Kind: "Configuration", Expression: `object.status.conditions.exists(c, c.type == 'Healthy'`},
+		{Name: "thing", Group: "example.org", Version: "v1alpha1", Kind: "Thing", Expression: `object.status.phase`},
+		{Name: "bucket", Group: "s3.aws.upbound.io", Version: "*", Kind: "*", Expression: `hasCondition(object, 'Ready')`, MessageExpression: `'bucket is ' + conditionReason(object, 'Ready')`},
+		{Name: "table", Group: "dynamodb.aws.upbound.io", Version: "*", Kind: "*", Expression: `hasCondition(object, 'Ready')`, MessageExpression: `hasCondition(object, 'Ready')`},
+	}}
+
+	got := make(map[string]string)
+	for key, err := range r.Validate() {
+		got[key] = err.Error()
+	}
+
+	want := map[string]string{
+		"configuration": "failed to compile query: ERROR: <input>:1:55: Syntax error: missing ')' at '<EOF>'\n | object.status.conditions.exists(c, c.type == 'Healthy'\n | ......................................................^",
+		"thing":         errCelQueryReturnTypeNotBoolOrMap,
+		"table":         "invalid messageExpression: " + errCelQueryReturnTypeNotString,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("r.Validate(): -want, +got:\n%s", diff)
+	}
+
+	names := make([]string, 0, len(r.Rules))
+	for _, rule := range r.Rules {
+		names = append(names, rule.Name)
+	}
+	if diff := cmp.Diff([]string{"deployment", "configmap", "bucket"}, names); diff != "" {
+		t.Errorf("r.Validate(): valid rules: -want, +got:\n%s", diff)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	items := make([]any, 1000)
+	for i := range items {
+		items[i] = int64(i)
+	}
+	obj := map[string]any{"items": items}
+
+	// This expression's cost grows with the square of the number of items.
+	expensive := `object.items.all(a, object.items.exists(b, a + b < 0))`
+
+	cases := map[string]struct {
+		reason string
+		r      Resolver
+		query  string
+		want   string
+	}{
+		"CostLimitExceeded": {
+			reason: "An evaluation exceeding its cost limit should return an error",
+			r:      Resolver{CostLimit: 1000},
+			query:  expensive,
+			want:   "celQuery exceeded its cost limit of 1000",
+		},
+		"TimeoutExceeded": {
+			reason: "An evaluation exceeding its timeout should return an error",
+			r:      Resolver{Timeout: time.Nanosecond},
+			query:  expensive,
+			want:   "celQuery exceeded its evaluation timeout of 1ns",
+		},
+		"WithinLimits": {
+			reason: "An evaluation within its limits should succeed",
+			r:      Resolver{CostLimit: 100000, Timeout: time.Minute},
+			query:  `object.items.exists(i, i == 10)`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.r.HealthStatusFromCelQuery(tc.query, obj, nil)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("%s\nHealthStatusFromCelQuery(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package cel
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/gvkkey"
+)
+
+// A FailurePolicy determines what happens when a rule fails to evaluate.
+type FailurePolicy string
+
+// Supported failure policies.
+const (
+	// FailurePolicyIgnore reports a Warning and falls back to the next
+	// health check.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+	// FailurePolicyFail reports the composed resource Degraded.
+	FailurePolicyFail FailurePolicy = "Fail"
+)
+
+// A Rule is a CEL health check for the composed resources it matches.
+type Rule struct {
+	// Name identifies the rule in errors.
+	Name string
+
+	// Group, Version and Kind select the kinds of composed resource the rule
+	// applies to. Each may be the wildcard *. An empty Group is the core API
+	// group.
+	Group   string
+	Version string
+	Kind    string
+
+	// Match optionally restricts the rule to the composed resources with the
+	// supplied composition resource names for which it returns true.
+	Match func(name string) bool
+
+	// Expression returns whether the composed resource is healthy, or a map
+	// with a status and an optional message.
+	Expression string
+
+	// MessageExpression optionally returns a string that replaces the
+	// message returned by Expression.
+	MessageExpression string
+
+	// Priority orders rules matching the same composed resource. Rules with a
+	// higher priority take precedence.
+	Priority int
+
+	// Override rules take precedence over every rule that isn't an override,
+	// regardless of priority.
+	Override bool
+
+	// FailurePolicy determines what happens when the rule fails to evaluate.
+	FailurePolicy FailurePolicy
+}
+
+// RuleFromKey returns a rule for a customization keyed by
+// <group>_<version>_<kind>, where the version and kind may be wildcards.
+func RuleFromKey(key, expression string) (Rule, error) {
+	if !gvkkey.Valid(key) {
+		return Rule{}, errors.New("key must be of the form <group>_<version>_<kind>, where the version and kind may be *")
+	}
+	parts := strings.Split(key, "_")
+	return Rule{
+		Name:       key,
+		Group:      parts[0],
+		Version:    parts[1],
+		Kind:       parts[2],
+		Expression: expression,
+	}, nil
+}
+
+// Matches returns true if the rule applies to the composed resource with the
+// supplied composition resource name and GVK.
+func (r Rule) Matches(name string, gvk schema.GroupVersionKind) bool {
+	if !matchPart(r.Group, gvk.Group) || !matchPart(r.Version, gvk.Version) || !matchPart(r.Kind, gvk.Kind) {
+		return false
+	}
+	return r.Match == nil || r.Match(name)
+}
+
+// specificity ranks rules with the same priority. Rules that select specific
+// composed resources are the most specific. Otherwise an exact kind is more
+// specific than an exact version, consistent with gvkkey.Candidates.
+func (r Rule) specificity() int {
+	s := 0
+	if r.Match != nil {
+		s += 8
+	}
+	if r.Group != gvkkey.Wildcard {
+		s += 4
+	}
+	if r.Kind != gvkkey.Wildcard {
+		s += 2
+	}
+	if r.Version != gvkkey.Wildcard {
+		s++
+	}
+	return s
+}
+
+// outranks returns true if the rule takes precedence over the supplied rule
+// when both match the same composed resource.
+func (r Rule) outranks(other Rule) bool {
+	if r.Override != other.Override {
+		return r.Override
+	}
+	if r.Priority != other.Priority {
+		return r.Priority > other.Priority
+	}
+	return r.specificity() > other.specificity()
+}
+
+func matchPart(pattern, value string) bool {
+	return pattern == gvkkey.Wildcard || pattern == value
+}
+
+// RuleFor returns the rule that applies to the composed resource with the
+// supplied composition resource name and GVK. When several rules match, an
+// override wins, then the one with the highest priority, then the most
+// specific, then the first.
+func (r Resolver) RuleFor(name string, gvk schema.GroupVersionKind) (Rule, bool) {
+	var best Rule
+	found := false
+	for _, rule := range r.Rules {
+		if !rule.Matches(name, gvk) {
+			continue
+		}
+		if found && !rule.outranks(best) {
+			continue
+		}
+		best, found = rule, true
+	}
+	return best, found
+}
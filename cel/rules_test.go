@@ -0,0 +1,148 @@
+package cel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRuleFromKey(t *testing.T) {
+	type want struct {
+		rule Rule
+		err  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		key    string
+		want   want
+	}{
+		"Exact": {
+			reason: "An exact key should be converted to a rule for that GVK",
+			key:    "apps_v1_Deployment",
+			want:   want{rule: Rule{Name: "apps_v1_Deployment", Group: "apps", Version: "v1", Kind: "Deployment", Expression: "true"}},
+		},
+		"CoreGroup": {
+			reason: "A key with an empty group should be converted to a rule for the core API group",
+			key:    "_v1_ConfigMap",
+			want:   want{rule: Rule{Name: "_v1_ConfigMap", Group: "", Version: "v1", Kind: "ConfigMap", Expression: "true"}},
+		},
+		"Wildcard": {
+			reason: "A wildcard key should be converted to a rule with wildcards",
+			key:    "s3.aws.upbound.io_*_*",
+			want:   want{rule: Rule{Name: "s3.aws.upbound.io_*_*", Group: "s3.aws.upbound.io", Version: "*", Kind: "*", Expression: "true"}},
+		},
+		"Invalid": {
+			reason: "An invalid key should return an error",
+			key:    "apps/v1/Deployment",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := RuleFromKey(tc.key, "true")
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nRuleFromKey(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.rule, rule, cmpopts.IgnoreFields(Rule{}, "Match")); diff != "" {
+				t.Errorf("%s\nRuleFromKey(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRuleFor(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	isWeb := func(name string) bool { return name == "web" }
+
+	type args struct {
+		name string
+		gvk  schema.GroupVersionKind
+	}
+	type want struct {
+		rule  string
+		found bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		rules  []Rule
+		args   args
+		want   want
+	}{
+		"NoMatch": {
+			reason: "No rule should be returned if none match",
+			rules:  []Rule{{Name: "job", Group: "batch", Version: "*", Kind: "Job"}},
+			args:   args{name: "web", gvk: deployment},
+			want:   want{found: false},
+		},
+		"ExactBeatsWildcard": {
+			reason: "An exact rule should take precedence over a wildcard rule",
+			rules: []Rule{
+				{Name: "apps", Group: "apps", Version: "*", Kind: "*"},
+				{Name: "exact", Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Name: "any-version", Group: "apps", Version: "*", Kind: "Deployment"},
+			},
+			args: args{name: "web", gvk: deployment},
+			want: want{rule: "exact", found: true},
+		},
+		"ResourceBeatsGVK": {
+			reason: "A rule selecting specific composed resources should take precedence over one that doesn't",
+			rules: []Rule{
+				{Name: "exact", Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Name: "web", Group: "*", Version: "*", Kind: "*", Match: isWeb},
+			},
+			args: args{name: "web", gvk: deployment},
+			want: want{rule: "web", found: true},
+		},
+		"ResourceNotMatched": {
+			reason: "A rule selecting other composed resources should not be returned",
+			rules: []Rule{
+				{Name: "exact", Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Name: "web", Group: "*", Version: "*", Kind: "*", Match: isWeb},
+			},
+			args: args{name: "api", gvk: deployment},
+			want: want{rule: "exact", found: true},
+		},
+		"OverrideBeatsPriority": {
+			reason: "An override should take precedence over a rule with a higher priority",
+			rules: []Rule{
+				{Name: "apps", Group: "apps", Version: "*", Kind: "*", Priority: 100},
+				{Name: "web", Group: "*", Version: "*", Kind: "*", Match: isWeb, Override: true},
+			},
+			args: args{name: "web", gvk: deployment},
+			want: want{rule: "web", found: true},
+		},
+		"PriorityBeatsSpecificity": {
+			reason: "A rule with a higher priority should take precedence over a more specific one",
+			rules: []Rule{
+				{Name: "exact", Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Name: "apps", Group: "apps", Version: "*", Kind: "*", Priority: 1},
+			},
+			args: args{name: "web", gvk: deployment},
+			want: want{rule: "apps", found: true},
+		},
+		"FirstWinsTie": {
+			reason: "The first of several equally specific rules with the same priority should be returned",
+			rules: []Rule{
+				{Name: "first", Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Name: "second", Group: "apps", Version: "v1", Kind: "Deployment"},
+			},
+			args: args{name: "web", gvk: deployment},
+			want: want{rule: "first", found: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := Resolver{Rules: tc.rules}
+			rule, found := r.RuleFor(tc.args.name, tc.args.gvk)
+			if diff := cmp.Diff(tc.want, want{rule: rule.Name, found: found}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("%s\nRuleFor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
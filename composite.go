@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/response"
+
+	"github.com/crossplane/function-auto-ready/cel"
+	"github.com/crossplane/function-auto-ready/features"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// ReasonCompositeNotReady is the reason of the result explaining why the
+// composite resource is not ready.
+const ReasonCompositeNotReady = "CompositeNotReady"
+
+// countReady returns how many of the desired composed resources matched by
+// the composite readiness policy are ready, and how many it matched.
+func countReady(cr *v1beta1.CompositeReadiness, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) (ready, total int, err error) {
+	selectors, err := compileSelectors(cr.Resources)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "cannot compile composite readiness selectors")
+	}
+	for name, dr := range desired {
+		if len(selectors) > 0 && !matchesAny(selectors, name, dr, observed[name]) {
+			continue
+		}
+		total++
+		if dr.Ready == resource.ReadyTrue {
+			ready++
+		}
+	}
+	return ready, total, nil
+}
+
+// requiredReady returns how many composed resources must be ready for the
+// composite resource to be ready.
+func requiredReady(cr *v1beta1.CompositeReadiness, total int) (int, error) {
+	switch cr.Policy {
+	case v1beta1.CompositeReadinessPolicyAny:
+		return 1, nil
+	case v1beta1.CompositeReadinessPolicyMinReady:
+		if cr.MinReady == nil || *cr.MinReady < 1 {
+			return 0, errors.New("minReady must be at least 1 when policy is MinReady")
+		}
+		return *cr.MinReady, nil
+	default:
+		return total, nil
+	}
+}
+
+// compositeReadiness returns the readiness of the composite resource according
+// to the input's composite readiness policy. It emits a result explaining why
+// the composite resource is not ready.
+func compositeReadiness(rsp *fnv1.RunFunctionResponse, in *v1beta1.Input, resolver cel.Resolver, oxr *resource.Composite, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) (resource.Ready, error) {
+	cr := in.CompositeReadiness
+
+	ready, total, err := countReady(cr, desired, observed)
+	if err != nil {
+		return resource.ReadyUnspecified, err
+	}
+	want, err := requiredReady(cr, total)
+	if err != nil {
+		return resource.ReadyUnspecified, err
+	}
+
+	reason := ""
+	switch {
+	case ready < want:
+		reason = fmt.Sprintf("%d/%d composed resources ready, need %d", ready, total, want)
+	case cr.Expression == "":
+	case !features.FeatureGate.Enabled(features.CELHealthcheckCustomizations):
+		response.Warning(rsp, errors.Errorf("ignoring composite readiness expression: the %s alpha feature is not enabled", features.CELHealthcheckCustomizations))
+	default:
+		xrReady, err := resolver.HealthDeriveFromCelQuery(cr.Expression, oxr.Resource.Object, nil)
+		if err != nil {
+			response.Warning(rsp, errors.Wrap(err, "cannot evaluate composite readiness expression"))
+			reason = "composite readiness expression could not be evaluated"
+			break
+		}
+		if xrReady != resource.ReadyTrue {
+			reason = "composite readiness expression evaluated to false"
+		}
+	}
+
+	if reason == "" {
+		return resource.ReadyTrue, nil
+	}
+
+	r := response.Normal(rsp, fmt.Sprintf("composite resource not ready: %s", reason)).WithReason(ReasonCompositeNotReady)
+	if in.ResultTarget == v1beta1.TargetCompositeAndClaim {
+		r.TargetCompositeAndClaim()
+	}
+	return resource.ReadyFalse, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// dependencyGraph maps a composed resource to the composed resources it
+// depends on.
+type dependencyGraph map[resource.Name][]resource.Name
+
+// newDependencyGraph builds a dependencyGraph from the supplied dependencies.
+// It returns an error if a dependency names a composed resource that isn't
+// desired.
+func newDependencyGraph(deps []v1beta1.Dependency, desired map[resource.Name]*resource.DesiredComposed) (dependencyGraph, error) {
+	g := make(dependencyGraph)
+	for i, d := range deps {
+		name := resource.Name(d.Resource)
+		if _, ok := desired[name]; !ok {
+			return nil, errors.Errorf("dependencies[%d].resource: unknown composed resource %q", i, name)
+		}
+		for j, on := range d.DependsOn {
+			if _, ok := desired[resource.Name(on)]; !ok {
+				return nil, errors.Errorf("dependencies[%d].dependsOn[%d]: unknown composed resource %q", i, j, on)
+			}
+			g[name] = append(g[name], resource.Name(on))
+		}
+	}
+	return g, nil
+}
+
+// Sorted returns the composed resources in the graph ordered so that every
+// composed resource appears after the composed resources it depends on. It
+// returns an error describing the cycle if the graph contains one.
+func (g dependencyGraph) Sorted() ([]resource.Name, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[resource.Name]int)
+	sorted := make([]resource.Name, 0, len(g))
+	path := make([]resource.Name, 0)
+
+	var visit func(n resource.Name) error
+	visit = func(n resource.Name) error {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := make([]string, 0, len(path)+1)
+			for _, p := range path[slices.Index(path, n):] {
+				cycle = append(cycle, string(p))
+			}
+			cycle = append(cycle, string(n))
+			return errors.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[n] = visiting
+		path = append(path, n)
+		for _, dep := range g[n] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = visited
+		sorted = append(sorted, n)
+		return nil
+	}
+
+	for _, n := range slices.Sorted(maps.Keys(g)) {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// applyDependencies un-readies composed resources this Function marked ready
+// while any of their dependencies are not ready. A healthy composed resource
+// that is blocked is reported as progressing, naming the dependency it's
+// waiting for. Optional composed resources are never blocked.
+func applyDependencies(in *v1beta1.Input, desired map[resource.Name]*resource.DesiredComposed, health map[resource.Name]healthchecks.HealthStatus, optional map[resource.Name]bool) error {
+	g, err := newDependencyGraph(in.Dependencies, desired)
+	if err != nil {
+		return err
+	}
+	sorted, err := g.Sorted()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sorted {
+		// We only have an opinion about the readiness of composed resources
+		// we evaluated, and only need to act on those we marked ready.
+		h, evaluated := health[name]
+		if !evaluated || optional[name] {
+			continue
+		}
+		dr := desired[name]
+		if dr.Ready != resource.ReadyTrue {
+			continue
+		}
+
+		for _, dep := range g[name] {
+			if dd, ok := desired[dep]; ok && dd.Ready == resource.ReadyTrue {
+				continue
+			}
+			dr.Ready = resource.ReadyUnspecified
+			if h.IsHealthy() {
+				health[name] = healthchecks.Progressing(fmt.Sprintf("waiting for dependency %s to be ready", dep))
+			}
+			break
+		}
+	}
+	return nil
+}
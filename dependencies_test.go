@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+func TestDependencyGraphSorted(t *testing.T) {
+	type want struct {
+		sorted []resource.Name
+		err    string
+	}
+
+	cases := map[string]struct {
+		reason  string
+		deps    []v1beta1.Dependency
+		desired []resource.Name
+		want    want
+	}{
+		"NoDependencies": {
+			reason: "An empty graph should sort to nothing",
+			want:   want{sorted: []resource.Name{}},
+		},
+		"Chain": {
+			reason: "Composed resources should appear after the composed resources they depend on",
+			deps: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret"}},
+				{Resource: "secret", DependsOn: []string{"database"}},
+			},
+			desired: []resource.Name{"app", "secret", "database"},
+			want:    want{sorted: []resource.Name{"database", "secret", "app"}},
+		},
+		"Diamond": {
+			reason: "Shared dependencies should only appear once",
+			deps: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret", "config"}},
+				{Resource: "secret", DependsOn: []string{"namespace"}},
+				{Resource: "config", DependsOn: []string{"namespace"}},
+			},
+			desired: []resource.Name{"app", "secret", "config", "namespace"},
+			want:    want{sorted: []resource.Name{"namespace", "secret", "config", "app"}},
+		},
+		"Cycle": {
+			reason: "A cycle should return an error describing it",
+			deps: []v1beta1.Dependency{
+				{Resource: "a", DependsOn: []string{"b"}},
+				{Resource: "b", DependsOn: []string{"c"}},
+				{Resource: "c", DependsOn: []string{"a"}},
+			},
+			desired: []resource.Name{"a", "b", "c"},
+			want:    want{err: "dependency cycle: a -> b -> c -> a"},
+		},
+		"SelfDependency": {
+			reason: "A composed resource depending on itself is a cycle",
+			deps: []v1beta1.Dependency{
+				{Resource: "a", DependsOn: []string{"a"}},
+			},
+			desired: []resource.Name{"a"},
+			want:    want{err: "dependency cycle: a -> a"},
+		},
+		"UnknownResource": {
+			reason: "A dependency of a composed resource that isn't desired should return an error",
+			deps: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret"}},
+			},
+			desired: []resource.Name{"secret"},
+			want:    want{err: `dependencies[0].resource: unknown composed resource "app"`},
+		},
+		"UnknownDependency": {
+			reason: "A dependency on a composed resource that isn't desired should return an error",
+			deps: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret", "sercet"}},
+			},
+			desired: []resource.Name{"app", "secret"},
+			want:    want{err: `dependencies[0].dependsOn[1]: unknown composed resource "sercet"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired := make(map[resource.Name]*resource.DesiredComposed, len(tc.desired))
+			for _, n := range tc.desired {
+				desired[n] = resource.NewDesiredComposed()
+			}
+			var sorted []resource.Name
+			g, err := newDependencyGraph(tc.deps, desired)
+			if err == nil {
+				sorted, err = g.Sorted()
+			}
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
+				t.Errorf("%s\nSorted(): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sorted, sorted); err == nil && diff != "" {
+				t.Errorf("%s\nSorted(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/gvkkey"
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+// gvkDurations is a duration that can be overridden for specific kinds of
+// composed resource. Overrides are keyed by <group>_<version>_<kind>, where
+// the version and kind may be wildcards.
+type gvkDurations struct {
+	fallback  time.Duration
+	overrides map[string]time.Duration
+}
+
+// parseGVKDurations parses a fallback duration and its per-GVK overrides. An
+// empty fallback is parsed as zero.
+func parseGVKDurations(fallback string, overrides map[string]string) (gvkDurations, error) {
+	d := gvkDurations{overrides: make(map[string]time.Duration, len(overrides))}
+	if fallback != "" {
+		dur, err := time.ParseDuration(fallback)
+		if err != nil {
+			return d, errors.Wrapf(err, "cannot parse duration %q", fallback)
+		}
+		d.fallback = dur
+	}
+	for k, v := range overrides {
+		if !gvkkey.Valid(k) {
+			return d, errors.Errorf("invalid key %q: must be of the form <group>_<version>_<kind>, where the version and kind may be *", k)
+		}
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return d, errors.Wrapf(err, "cannot parse duration %q for %q", v, k)
+		}
+		d.overrides[k] = dur
+	}
+	return d, nil
+}
+
+// IsZero returns true if no duration is configured for any kind.
+func (d gvkDurations) IsZero() bool {
+	if d.fallback != 0 {
+		return false
+	}
+	for _, dur := range d.overrides {
+		if dur != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// For returns the duration for the supplied kind of composed resource.
+func (d gvkDurations) For(gvk schema.GroupVersionKind) time.Duration {
+	if dur, ok := gvkkey.Lookup(d.overrides, gvk); ok {
+		return dur
+	}
+	return d.fallback
+}
+
+// shortest returns the shorter of the supplied durations, treating zero as
+// unset.
+func shortest(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// readinessTTL returns the TTL of the response. It returns readyTTL if every
+// composed resource with a recorded health is ready, or the shortest
+// progressing TTL of any that isn't. It returns ttl when the applicable TTL
+// isn't configured.
+func readinessTTL(ttl, readyTTL time.Duration, progressingTTL gvkDurations, desired map[resource.Name]*resource.DesiredComposed, health map[resource.Name]healthchecks.HealthStatus, gvks map[resource.Name]schema.GroupVersionKind) time.Duration {
+	var progressing time.Duration
+	allReady := true
+	for name := range health {
+		if desired[name].Ready == resource.ReadyTrue {
+			continue
+		}
+		allReady = false
+		progressing = shortest(progressing, progressingTTL.For(gvks[name]))
+	}
+
+	switch {
+	case allReady && readyTTL != 0:
+		return readyTTL
+	case !allReady && progressing != 0:
+		return progressing
+	default:
+		return ttl
+	}
+}
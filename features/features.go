@@ -8,6 +8,10 @@ const (
 	// CELHealthcheckCustomizations enables the capability to define healthchecks as CEL queries.
 	// CEL-based healthchecks overwrite the built-in Go-based healthchecks if defined for one of the supported types.
 	CELHealthcheckCustomizations featuregate.Feature = "CELHealthcheckCustomizations"
+
+	// LuaHealthcheckCustomizations enables the capability to define healthchecks as Argo CD style Lua scripts.
+	// Lua-based healthchecks overwrite the built-in Go-based healthchecks if defined for one of the supported types.
+	LuaHealthcheckCustomizations featuregate.Feature = "LuaHealthcheckCustomizations"
 )
 
 // defaultAutoReadyFeatureGates consists of all known function-auto-ready specific feature keys.
@@ -15,6 +19,7 @@ const (
 // its default state and maturity stage (Alpha, Beta, or GA).
 var defaultAutoReadyFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	CELHealthcheckCustomizations: {Default: false, PreRelease: featuregate.Alpha},
+	LuaHealthcheckCustomizations: {Default: false, PreRelease: featuregate.Alpha},
 }
 
 // FeatureGate is the shared global MutableFeatureGate for function-auto-ready.
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"maps"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -23,6 +25,7 @@ import (
 	"github.com/crossplane/function-auto-ready/features"
 	"github.com/crossplane/function-auto-ready/healthchecks"
 	"github.com/crossplane/function-auto-ready/input/v1beta1"
+	"github.com/crossplane/function-auto-ready/lua"
 )
 
 // Function returns whatever response you ask it to.
@@ -31,6 +34,30 @@ type Function struct {
 
 	log logging.Logger
 	ttl time.Duration
+
+	// programs caches compiled CEL programs across requests.
+	programs *cel.ProgramCache
+
+	// celCostLimit and celTimeout are the most the input may set the CEL
+	// cost limit and evaluation timeout to. Zero means no ceiling.
+	celCostLimit uint64
+	celTimeout   time.Duration
+
+	// luaInstructionLimit and luaTimeout are the most the input may set the
+	// Lua instruction limit and evaluation timeout to. Zero means no ceiling.
+	luaInstructionLimit uint64
+	luaTimeout          time.Duration
+
+	// clock returns the current time. It defaults to time.Now.
+	clock func() time.Time
+}
+
+// now returns the current time according to the Function's clock.
+func (f *Function) now() time.Time {
+	if f.clock == nil {
+		return time.Now()
+	}
+	return f.clock()
 }
 
 // RunFunction runs the Function.
@@ -53,11 +80,60 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 		rsp.Meta.Ttl = durationpb.New(dur)
 	}
 
+	minReady, err := parseGVKDurations(in.MinReadyDuration, in.MinReadyDurationOverrides)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot parse minimum ready duration"))
+		return rsp, nil
+	}
+
+	var readyTTL time.Duration
+	if in.ReadyTTL != "" {
+		readyTTL, err = time.ParseDuration(in.ReadyTTL)
+		if err != nil {
+			response.Fatal(rsp, errors.Wrapf(err, "cannot parse ready ttl"))
+			return rsp, nil
+		}
+	}
+
+	progressingTTL, err := parseGVKDurations(in.ProgressingTTL, in.ProgressingTTLOverrides)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot parse progressing ttl"))
+		return rsp, nil
+	}
+
+	deadlines, err := parseGVKDurations(in.ProgressDeadline, in.ProgressDeadlineOverrides)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot parse progress deadline"))
+		return rsp, nil
+	}
+
+	stalePolicies, err := parseStaleStatusPolicies(in.StaleStatusPolicy, in.StaleStatusPolicyOverrides)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot parse stale status policy"))
+		return rsp, nil
+	}
+
+	catalog, err := healthchecks.NewCatalog(in.HealthCheckCatalog)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot enable health check catalog"))
+		return rsp, nil
+	}
+
 	oxr, err := request.GetObservedCompositeResource(req)
 	if err != nil {
 		response.Fatal(rsp, errors.Wrapf(err, "cannot get observed composite resource from %T", req))
 		return rsp, nil
 	}
+
+	dxr, err := request.GetDesiredCompositeResource(req)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrapf(err, "cannot get desired composite resource from %T", req))
+		return rsp, nil
+	}
+	dxr.Ready = readyFromProto(req.GetDesired().GetComposite().GetReady())
+	// Only set the desired composite resource if we change it, to avoid
+	// overwriting the desired state accumulated by previous Functions.
+	dxrChanged := false
 	log := f.log.WithValues(
 		"xr-apiversion", oxr.Resource.GetAPIVersion(),
 		"xr-kind", oxr.Resource.GetKind(),
@@ -78,6 +154,69 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 
 	f.log.Debug("Found desired resources", "count", len(desired))
 
+	// Only evaluate the composed resources selected by the input. Resources
+	// that aren't selected are passed through untouched.
+	selected, err := selectResources(in, desired, observed)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot select composed resources"))
+		return rsp, nil
+	}
+
+	f.log.Debug("Selected desired resources", "count", len(selected))
+
+	optional, err := optionalResources(in, selected, observed)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot determine optional composed resources"))
+		return rsp, nil
+	}
+
+	// health records the outcome of the first health check that evaluated
+	// each composed resource. Later passes skip resources that already have
+	// a recorded health, and the outcome is reported once all passes ran.
+	health := make(map[resource.Name]healthchecks.HealthStatus)
+
+	// celEvaluated records the composed resources whose health was
+	// determined by a CEL health check.
+	celEvaluated := make(map[resource.Name]bool)
+
+	// CEL expressions can refer to the observed composite resource, the
+	// function context and every observed composed resource by name.
+	resources := make(map[string]any, len(observed))
+	for name, or := range observed {
+		resources[string(name)] = or.Resource.Object
+	}
+	costLimit, timeout, err := f.celLimits(in)
+	if err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot determine CEL limits"))
+		return rsp, nil
+	}
+	celResolver := cel.Resolver{
+		Programs:  f.programs,
+		Composite: oxr.Resource.Object,
+		Context:   req.GetContext().AsMap(),
+		Resources: resources,
+		CostLimit: costLimit,
+		Timeout:   timeout,
+		Now:       f.clock,
+	}
+
+	// Before any health check runs, composed resources whose status doesn't
+	// reflect their latest spec yet are progressing, regardless of what
+	// their status says.
+	for name, dr := range selected {
+		or, ok := observed[name]
+		if !ok || dr.Ready != resource.ReadyUnspecified {
+			continue
+		}
+		if stalePolicies.For(or.Resource.GroupVersionKind()) == v1beta1.StaleStatusPolicyIgnore {
+			continue
+		}
+		if msg, stale := staleStatus(or.Resource); stale {
+			log.Debug("Composed resource status is stale", "composed-resource-name", name, "message", msg)
+			health[name] = healthchecks.Progressing(msg)
+		}
+	}
+
 	// First mark resources based on CEL customizations if CELHealthcheckCustomizations alpha feature is enabled
 	if features.FeatureGate.Enabled(features.CELHealthcheckCustomizations) {
 		// Evaluate the CEL health checks customizations
@@ -95,11 +234,29 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 			maps.Copy(celHealthchecks, *in.CELHealthCheckCustomization)
 		}
 
-		celResolver := cel.Resolver{
-			HealthCheckRegistry: celHealthchecks,
+		// Customizations keyed by GVK or by composition resource name are
+		// converted to rules, and considered together with the input's rules.
+		rules, invalid := celRules(in, celHealthchecks, desired, observed)
+		celResolver.Rules = rules
+
+		// Compile every rule before evaluating any resources, so invalid
+		// rules are reported even if no composed resource of their kind
+		// exists yet.
+		maps.Copy(invalid, celResolver.Validate())
+		if len(invalid) > 0 {
+			msgs := make([]string, 0, len(invalid))
+			for _, key := range slices.Sorted(maps.Keys(invalid)) {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", key, invalid[key]))
+			}
+			err := errors.Errorf("invalid CEL health check customizations: %s", strings.Join(msgs, "; "))
+			if in.CELValidationPolicy == v1beta1.CELValidationPolicyFatal {
+				response.Fatal(rsp, err)
+				return rsp, nil
+			}
+			response.Warning(rsp, err)
 		}
 
-		for name, dr := range desired {
+		for name, dr := range selected {
 			log := log.WithValues("composed-resource-name", name)
 
 			// Skip if resource doesn't exist yet
@@ -108,30 +265,102 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 				continue
 			}
 
-			// Skip if readiness already explicitly set
+			// Skip if readiness already explicitly set, or already evaluated
 			if dr.Ready != resource.ReadyUnspecified {
 				continue
 			}
+			if _, evaluated := health[name]; evaluated {
+				continue
+			}
 
-			// Check if this resource type has a registered health check customization
+			// Check if a health check rule applies to this resource
 			gvk := or.Resource.GroupVersionKind()
 
-			if celQuery, found := celResolver.GetHealthCheck(gvk); found {
-				log.Debug("Using resource-specific health check customization", "gvk", gvk.String())
-				ready, err := celResolver.HealthDeriveFromCelQuery(celQuery, or.Resource.Object)
+			if rule, found := celResolver.RuleFor(string(name), gvk); found {
+				log.Debug("Using resource-specific health check customization", "gvk", gvk.String(), "rule", rule.Name)
+				h, err := celResolver.Evaluate(rule, or.Resource.Object, dr.Resource.Object)
+				if err != nil && rule.FailurePolicy == cel.FailurePolicyFail {
+					health[name] = healthchecks.Degraded(fmt.Sprintf("health check rule %s failed: %s", rule.Name, err))
+					celEvaluated[name] = true
+					continue
+				}
 				if err != nil {
 					response.Warning(rsp, err)
 					log.Debug(fmt.Sprintf("Encountered error during resource-specific health check customization evaluation: %s", err.Error()), "gvk", gvk.String())
 					continue
 				}
+				health[name] = h
+				celEvaluated[name] = true
+			}
+		}
+	} else {
+		if len(in.CELHealthCheckResourceOverrides) > 0 {
+			response.Warning(rsp, errors.Errorf("ignoring CEL health check resource overrides: the %s alpha feature is not enabled", features.CELHealthcheckCustomizations))
+		}
+		if len(in.CELHealthCheckRules) > 0 {
+			response.Warning(rsp, errors.Errorf("ignoring CEL health check rules: the %s alpha feature is not enabled", features.CELHealthcheckCustomizations))
+		}
+	}
+
+	// Next, mark resources based on Lua customizations if the
+	// LuaHealthcheckCustomizations alpha feature is enabled. Inline scripts
+	// take precedence over scripts passed via context.
+	if features.FeatureGate.Enabled(features.LuaHealthcheckCustomizations) {
+		var fromContext map[string]string
+		if in.LuaHealthCheckCustomizationFrom != nil {
+			fromContext = GetNestedMap(req.GetContext().AsMap(), *in.LuaHealthCheckCustomizationFrom)
+		}
+
+		instructionLimit, timeout, err := f.luaLimits(in)
+		if err != nil {
+			response.Fatal(rsp, errors.Wrap(err, "cannot determine Lua limits"))
+			return rsp, nil
+		}
 
-				dr.Ready = ready
+		luaResolver, invalid := lua.NewResolver(fromContext, in.LuaHealthCheckCustomization)
+		luaResolver.InstructionLimit = instructionLimit
+		luaResolver.Timeout = timeout
+		if len(invalid) > 0 {
+			msgs := make([]string, 0, len(invalid))
+			for _, key := range slices.Sorted(maps.Keys(invalid)) {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", key, invalid[key]))
 			}
+			response.Warning(rsp, errors.Errorf("invalid Lua health check customizations: %s", strings.Join(msgs, "; ")))
+		}
+
+		for name, dr := range selected {
+			log := log.WithValues("composed-resource-name", name)
+
+			// Skip if resource doesn't exist yet
+			or, ok := observed[name]
+			if !ok {
+				continue
+			}
+
+			// Skip if readiness already explicitly set, or already evaluated
+			if dr.Ready != resource.ReadyUnspecified {
+				continue
+			}
+			if _, evaluated := health[name]; evaluated {
+				continue
+			}
+
+			gk := or.Resource.GroupVersionKind().GroupKind()
+			if !luaResolver.HasHealthCheck(gk) {
+				continue
+			}
+			log.Debug("Using Lua health check customization", "gk", gk.String())
+			h, err := luaResolver.HealthStatus(gk, or.Resource.Object)
+			if err != nil {
+				response.Warning(rsp, errors.Wrapf(err, "cannot evaluate Lua health check for composed resource %q", name))
+				continue
+			}
+			health[name] = h
 		}
 	}
 
-	// Second, mark standard Kubernetes resources as ready using resource-specific health checks
-	for name, dr := range desired {
+	// Then, evaluate standard Kubernetes resources using resource-specific health checks
+	for name, dr := range selected {
 		log := log.WithValues("composed-resource-name", name)
 
 		// Skip if resource doesn't exist yet
@@ -140,36 +369,42 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 			continue
 		}
 
-		// Skip if readiness already explicitly set
+		// Skip if readiness already explicitly set, or already evaluated
 		if dr.Ready != resource.ReadyUnspecified {
 			continue
 		}
+		if _, evaluated := health[name]; evaluated {
+			continue
+		}
 
 		// Check if this resource type has a registered health check
 		// Get GVK from the unstructured object (apiVersion and kind fields)
 		// composed.Unstructured embeds unstructured.Unstructured, so we can use it directly
 		gvk := or.Resource.GroupVersionKind()
-		if healthCheck := healthchecks.GetHealthCheck(gvk); healthCheck != nil {
+		healthCheck := healthchecks.GetHealthCheck(gvk)
+		if healthCheck == nil {
+			// Fall back to the opted in health checks for popular CRDs
+			healthCheck = catalog.GetHealthCheck(gvk)
+		}
+		if healthCheck != nil {
 			log.Debug("Using resource-specific health check", "gvk", gvk.String())
-			if healthCheck(&or.Resource.Unstructured) {
-				log.Debug("Marked resource as ready via resource-specific health check", "gvk", gvk.String())
-				dr.Ready = resource.ReadyTrue
+			h := healthCheck(&or.Resource.Unstructured)
+			health[name] = h
+			switch h.Status {
+			case healthchecks.HealthStatusHealthy:
+				log.Debug("Resource-specific health check reports resource is healthy", "gvk", gvk.String())
+			case healthchecks.HealthStatusDegraded:
+				log.Debug("Resource-specific health check reports resource is degraded", "gvk", gvk.String(), "message", h.Message)
+			default:
+				log.Debug("Resource-specific health check reports resource is not ready yet", "gvk", gvk.String(), "status", h.Status, "message", h.Message)
 			}
 		}
 	}
 
-	// Third, check remaining resources using the Ready status condition
-	for name, dr := range desired {
+	// Last, check remaining resources using the Ready status condition
+	for name, dr := range selected {
 		log := log.WithValues("composed-resource-name", name)
 
-		// If this desired resource doesn't exist in the observed resources, it
-		// can't be ready because it doesn't yet exist.
-		or, ok := observed[name]
-		if !ok {
-			log.Debug("Ignoring desired resource that does not appear in observed resources")
-			continue
-		}
-
 		// A previous Function in the pipeline either said this resource was
 		// explicitly ready, or explicitly not ready. We only want to
 		// automatically determine readiness for desired resources where no
@@ -179,6 +414,20 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 			continue
 		}
 
+		// A previous pass already determined the health of this resource.
+		if _, evaluated := health[name]; evaluated {
+			continue
+		}
+
+		// If this desired resource doesn't exist in the observed resources, it
+		// can't be ready because it doesn't yet exist.
+		or, ok := observed[name]
+		if !ok {
+			log.Debug("Ignoring desired resource that does not appear in observed resources")
+			health[name] = healthchecks.Progressing("resource has not been created yet")
+			continue
+		}
+
 		// Now we know this resource exists, and no Function that ran before us
 		// has an opinion about whether it's ready.
 
@@ -186,9 +435,128 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 		// If this observed resource has a status condition with type: Ready,
 		// status: True, we set its readiness to true.
 		c := or.Resource.GetCondition(xpv2.TypeReady)
+		if c.Status == corev1.ConditionTrue && in.RequireSynced {
+			// The resource must also be Synced, i.e. its latest spec was
+			// applied, before it's ready.
+			health[name] = syncedHealth(or.Resource, c)
+			continue
+		}
 		if c.Status == corev1.ConditionTrue {
 			log.Debug("Automatically determined that composed resource is ready")
-			dr.Ready = resource.ReadyTrue
+			health[name] = healthchecks.Healthy(c.Message)
+			continue
+		}
+		health[name] = readyConditionHealth(c)
+	}
+
+	gvks := composedGVKs(health, desired, observed)
+
+	// requeue is how soon we need to be called again to act on a minimum
+	// ready duration or progress deadline. Zero means we don't.
+	var requeue time.Duration
+
+	// Composed resources that have been progressing for longer than their
+	// progress deadline are considered degraded. The time each started
+	// progressing is persisted on the composite resource.
+	if !deadlines.IsZero() {
+		since, wait := applyProgressDeadline(f.now(), deadlines, health, gvks, getTimestamps(oxr, StatusFieldProgressingSince))
+		if err := setTimestamps(dxr, StatusFieldProgressingSince, since); err != nil {
+			response.Fatal(rsp, errors.Wrap(err, "cannot persist when composed resources started progressing"))
+			return rsp, nil
+		}
+		dxrChanged = true
+		requeue = shortest(requeue, wait)
+	}
+
+	// Composed resources must pass their health check continuously for their
+	// minimum ready duration before they're reported ready. The time each was
+	// first seen healthy is persisted on the composite resource.
+	if !minReady.IsZero() {
+		since, wait := applyMinReadyDuration(f.now(), minReady, health, gvks, getTimestamps(oxr, StatusFieldHealthySince))
+		if err := setTimestamps(dxr, StatusFieldHealthySince, since); err != nil {
+			response.Fatal(rsp, errors.Wrap(err, "cannot persist when composed resources became healthy"))
+			return rsp, nil
+		}
+		dxrChanged = true
+		requeue = shortest(requeue, wait)
+	}
+
+	// Finally, translate the health of each evaluated resource into readiness.
+	// Healthy resources are always marked ready. Degraded resources are only
+	// marked explicitly not ready if the unhealthy policy asks for it. CEL
+	// health checks have always marked resources not ready, so that's what
+	// they do unless the policy says otherwise.
+	for name, h := range health {
+		policy := in.UnhealthyPolicy
+		if policy == "" && celEvaluated[name] {
+			policy = v1beta1.UnhealthyPolicyFalse
+		}
+		switch {
+		case h.IsHealthy():
+			desired[name].Ready = resource.ReadyTrue
+		case h.Status == healthchecks.HealthStatusDegraded && policy == v1beta1.UnhealthyPolicyFalse:
+			desired[name].Ready = resource.ReadyFalse
+		}
+	}
+
+	// Every member of a readiness group is ready once a quorum of its
+	// members are healthy.
+	if err := applyReadinessGroups(rsp, in, desired, observed, health); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot apply readiness groups"))
+		return rsp, nil
+	}
+
+	// Optional resources never block the readiness of the composite resource.
+	// Mark them ready once they exist, or even before then if the input says
+	// so. Their true health is still reported below.
+	for name := range health {
+		if !optional[name] {
+			continue
+		}
+		if _, exists := observed[name]; !exists && in.OptionalResourcePolicy != v1beta1.OptionalResourcePolicyAlways {
+			continue
+		}
+		log.Debug("Marking optional composed resource as ready", "composed-resource-name", name)
+		desired[name].Ready = resource.ReadyTrue
+	}
+
+	// A composed resource is only ready once the composed resources it
+	// depends on are ready. This happens before health is reported, so
+	// blocked resources are reported as progressing.
+	if err := applyDependencies(in, desired, health, optional); err != nil {
+		response.Fatal(rsp, errors.Wrap(err, "cannot apply composed resource dependencies"))
+		return rsp, nil
+	}
+
+	reportHealth(rsp, in.ResultTarget, desired, observed, health, optional)
+
+	// Set the readiness of the composite resource itself if the input asks
+	// us to, unless a previous Function already has an opinion about it.
+	if in.CompositeReadiness != nil {
+		if dxr.Ready != resource.ReadyUnspecified {
+			log.Debug("Ignoring composite readiness policy for composite resource that already has explicit readiness", "ready", dxr.Ready)
+		} else {
+			ready, err := compositeReadiness(rsp, in, celResolver, oxr, desired, observed)
+			if err != nil {
+				response.Fatal(rsp, errors.Wrap(err, "cannot determine composite resource readiness"))
+				return rsp, nil
+			}
+			dxr.Ready = ready
+			dxrChanged = true
+		}
+	}
+
+	// Pick a TTL based on whether everything is ready, so Crossplane calls us
+	// again quickly while composed resources converge and backs off once
+	// they're ready. Make sure we're called again in time to act on a minimum
+	// ready duration or progress deadline.
+	ttl := readinessTTL(rsp.GetMeta().GetTtl().AsDuration(), readyTTL, progressingTTL, desired, health, gvks)
+	rsp.Meta.Ttl = durationpb.New(shortest(ttl, requeue))
+
+	if dxrChanged {
+		if err := response.SetDesiredCompositeResource(rsp, dxr); err != nil {
+			response.Fatal(rsp, errors.Wrapf(err, "cannot set desired composite resource from %T", req))
+			return rsp, nil
 		}
 	}
 
@@ -200,6 +568,85 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 	return rsp, nil
 }
 
+// celLimits returns the CEL cost limit and evaluation timeout requested by
+// the input, clamped to the Function's ceilings. It returns the ceilings if
+// the input doesn't request limits.
+func (f *Function) celLimits(in *v1beta1.Input) (uint64, time.Duration, error) {
+	costLimit, err := clampLimit(f.celCostLimit, in.CELCostLimit, "celCostLimit")
+	if err != nil {
+		return 0, 0, err
+	}
+	timeout, err := clampTimeout(f.celTimeout, in.CELEvaluationTimeout, "celEvaluationTimeout")
+	if err != nil {
+		return 0, 0, err
+	}
+	return costLimit, timeout, nil
+}
+
+// luaLimits returns the Lua instruction limit and evaluation timeout
+// requested by the input, clamped to the Function's ceilings. It returns the
+// ceilings if the input doesn't request limits.
+func (f *Function) luaLimits(in *v1beta1.Input) (uint64, time.Duration, error) {
+	instructionLimit, err := clampLimit(f.luaInstructionLimit, in.LuaInstructionLimit, "luaInstructionLimit")
+	if err != nil {
+		return 0, 0, err
+	}
+	timeout, err := clampTimeout(f.luaTimeout, in.LuaEvaluationTimeout, "luaEvaluationTimeout")
+	if err != nil {
+		return 0, 0, err
+	}
+	return instructionLimit, timeout, nil
+}
+
+// clampLimit returns the requested limit, clamped to the supplied ceiling. It
+// returns the ceiling if no limit is requested. A zero ceiling means no
+// ceiling.
+func clampLimit(ceiling uint64, requested *int64, field string) (uint64, error) {
+	if requested == nil {
+		return ceiling, nil
+	}
+	if *requested < 1 {
+		return 0, errors.Errorf("%s must be at least 1", field)
+	}
+	limit := uint64(*requested)
+	if ceiling > 0 && limit > ceiling {
+		limit = ceiling
+	}
+	return limit, nil
+}
+
+// clampTimeout returns the requested timeout, in time.Duration format, clamped
+// to the supplied ceiling. It returns the ceiling if no timeout is requested.
+// A zero ceiling means no ceiling.
+func clampTimeout(ceiling time.Duration, requested, field string) (time.Duration, error) {
+	if requested == "" {
+		return ceiling, nil
+	}
+	d, err := time.ParseDuration(requested)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot parse %s", field)
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("%s must be positive", field)
+	}
+	if ceiling > 0 && d > ceiling {
+		d = ceiling
+	}
+	return d, nil
+}
+
+// readyFromProto converts the readiness of a resource in a request.
+func readyFromProto(r fnv1.Ready) resource.Ready {
+	switch r {
+	case fnv1.Ready_READY_TRUE:
+		return resource.ReadyTrue
+	case fnv1.Ready_READY_FALSE:
+		return resource.ReadyFalse
+	default:
+		return resource.ReadyUnspecified
+	}
+}
+
 func GetNestedMap(context map[string]any, key string) map[string]string {
 	parts, err := ParseNestedKey(key)
 	if err != nil {
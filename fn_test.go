@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,12 +10,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/function-auto-ready/features"
 	"github.com/crossplane/function-auto-ready/input/v1beta1"
 	"github.com/crossplane/function-sdk-go/logging"
 	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
 	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composite"
 	"github.com/crossplane/function-sdk-go/response"
 )
 
@@ -31,6 +34,7 @@ func TestRunFunction(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
+		clock  func() time.Time
 		args   args
 		want   want
 	}{
@@ -86,6 +90,14 @@ func TestRunFunction(t *testing.T) {
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
 							// This function doesn't care about the desired
@@ -157,6 +169,14 @@ func TestRunFunction(t *testing.T) {
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
 							// This function doesn't care about the desired
@@ -218,6 +238,14 @@ func TestRunFunction(t *testing.T) {
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
 							// This function doesn't care about the desired
@@ -285,6 +313,14 @@ func TestRunFunction(t *testing.T) {
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
 					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
 							// This function doesn't care about the desired
@@ -300,117 +336,16 @@ func TestRunFunction(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
-			rsp, err := f.RunFunction(tc.args.ctx, tc.args.req)
-
-			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
-			}
-
-			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestRunFunctionCacheTTL(t *testing.T) {
-	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"},"spec":{"count":1}}`
-
-	cases := map[string]struct {
-		reason string
-		input  *v1beta1.Input
-		want   *fnv1.RunFunctionResponse
-	}{
-		"InputTTL": {
-			reason: "Set the response ttl value from the input specified",
-			input:  &v1beta1.Input{TTL: "5m"},
-			want: &fnv1.RunFunctionResponse{
-				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(5 * time.Minute)},
-				Desired: &fnv1.State{
-					Composite: &fnv1.Resource{
-						Resource: resource.MustStructJSON(xr),
-					},
-					Resources: map[string]*fnv1.Resource{
-						"second": {
-							Resource: resource.MustStructJSON(xr),
-						},
-					},
-				},
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			f := &Function{log: logging.NewNopLogger()}
-			req := &fnv1.RunFunctionRequest{
-				Input: resource.MustStructObject(tc.input),
-				Observed: &fnv1.State{
-					Composite: &fnv1.Resource{
-						Resource: resource.MustStructJSON(xr),
-					},
-					Resources: map[string]*fnv1.Resource{},
-				},
-				Desired: &fnv1.State{
-					Composite: &fnv1.Resource{
-						Resource: resource.MustStructJSON(xr),
-					},
-					Resources: map[string]*fnv1.Resource{
-						"second": {
-							Resource: resource.MustStructJSON(xr),
-						},
-					},
-				},
-			}
-			rsp, err := f.RunFunction(context.Background(), req)
-			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
-			}
-			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestCELHealthcheckCustomizations(t *testing.T) {
-	reqContext := resource.MustStructJSON(`{
-		"apiextensions.crossplane.io/environment": {
-			"celHealthCheckCustomizations": {
-				"pkg.crossplane.io_v1_Configuration": "object.status.conditions.exists(c, c.type == 'Installed' && c.status == 'True') && object.status.conditions.exists(c, c.type == 'Healthy' && c.status == 'True')"
-			}
-		}
-	}`)
-
-	type args struct {
-		ctx context.Context
-		req *fnv1.RunFunctionRequest
-	}
-	type want struct {
-		rsp *fnv1.RunFunctionResponse
-		err error
-	}
-
-	cases := map[string]struct {
-		reason string
-		args   args
-		want   want
-	}{
-		"CELHealthCheckFromContext": {
-			reason: "A Configuration should be ready when both conditions are true and CEL customization in context is present",
+		"SelectorsHonoured": {
+			reason: "Only composed resources selected by include and not matched by exclude should be evaluated by the built-in and Ready condition health checks, others should pass through untouched",
 			args: args{
 				req: &fnv1.RunFunctionRequest{
-					Meta:    &fnv1.RequestMeta{Tag: "hello"},
-					Context: reqContext,
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
 					Input: resource.MustStructJSON(`{
-						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
 						"kind": "Input",
-						"celHealthCheckCustomizationFrom": "[apiextensions.crossplane.io/environment].celHealthCheckCustomizations"
+						"include": [{"name": "web*"}],
+						"exclude": [{"matchLabels": {"autoready": "skip"}}]
 					}`),
 					Observed: &fnv1.State{
 						Composite: &fnv1.Resource{
@@ -423,39 +358,105 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 							}`),
 						},
 						Resources: map[string]*fnv1.Resource{
-							"my-configuration": {
+							"web": {
 								Resource: resource.MustStructJSON(`{
-									"apiVersion": "pkg.crossplane.io/v1",
-									"kind": "Configuration",
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
 									"metadata": {
-										"name": "my-configuration"
+										"name": "web"
 									},
 									"spec": {
-										"package": "xpkg.crossplane.io/test-package:0.0.1"
+										"replicas": 1
 									},
 									"status": {
+										"updatedReplicas": 1,
+										"availableReplicas": 1,
 										"conditions": [
 											{
-												"type": "Installed",
+												"type": "Available",
 												"status": "True"
-											},
+											}
+										]
+									}
+								}`),
+							},
+							"web-database": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
+									"metadata": {
+										"name": "web-database"
+									},
+									"status": {
+										"conditions": [
 											{
-												"type": "Healthy",
+												"type": "Ready",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+							"web-canary": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web-canary"
+									},
+									"spec": {
+										"replicas": 1
+									},
+									"status": {
+										"updatedReplicas": 1,
+										"availableReplicas": 1,
+										"conditions": [
+											{
+												"type": "Available",
 												"status": "True"
 											}
 										]
 									}
 								}`),
 							},
+							"cache": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
+									"metadata": {
+										"name": "cache"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating"
+											}
+										]
+									}
+								}`),
+							},
 						},
 					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+							"web-database": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+							"web-canary": {
+								Resource: resource.MustStructJSON(`{
+									"metadata": {
+										"labels": {
+											"autoready": "skip"
+										}
+									}
+								}`),
+							},
+							"cache": {
 								Resource: resource.MustStructJSON(`{}`),
 							},
 						},
@@ -464,34 +465,52 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
-					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
-					Context: reqContext,
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+							"web-database": {
 								Resource: resource.MustStructJSON(`{}`),
 								Ready:    fnv1.Ready_READY_TRUE,
 							},
+							"web-canary": {
+								Resource: resource.MustStructJSON(`{
+									"metadata": {
+										"labels": {
+											"autoready": "skip"
+										}
+									}
+								}`),
+							},
+							"cache": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
 						},
 					},
 				},
 			},
 		},
-		"CELHealthCheckFromInput": {
-			reason: "A Configuration should be ready when both conditions are true and CEL customization in Input is present",
+		"MinReadyDurationFirstSeenHealthy": {
+			reason: "A composed resource first seen healthy should not be ready until its minimum ready duration elapses, and when it became healthy should be persisted in the composite resource's status",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
 			args: args{
 				req: &fnv1.RunFunctionRequest{
 					Meta: &fnv1.RequestMeta{Tag: "hello"},
 					Input: resource.MustStructJSON(`{
-						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
 						"kind": "Input",
-						"celHealthCheckCustomization": {
-							"pkg.crossplane.io_v1_Configuration": "object.status.conditions.exists(c, c.type == 'Installed' && c.status == 'True') && object.status.conditions.exists(c, c.type == 'Healthy' && c.status == 'True')"
-						}
+						"minReadyDuration": "30s"
 					}`),
 					Observed: &fnv1.State{
 						Composite: &fnv1.Resource{
@@ -504,24 +523,17 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 							}`),
 						},
 						Resources: map[string]*fnv1.Resource{
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{
-									"apiVersion": "pkg.crossplane.io/v1",
-									"kind": "Configuration",
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
 									"metadata": {
-										"name": "my-configuration"
-									},
-									"spec": {
-										"package": "xpkg.crossplane.io/test-package:0.0.1"
+										"name": "app"
 									},
 									"status": {
 										"conditions": [
 											{
-												"type": "Installed",
-												"status": "True"
-											},
-											{
-												"type": "Healthy",
+												"type": "Ready",
 												"status": "True"
 											}
 										]
@@ -532,11 +544,7 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
 							},
 						},
@@ -545,35 +553,55 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
-					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(30 * time.Second)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "testcomposed app: healthy for 0s, waiting until healthy for 30s",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: testcomposed app: healthy for 0s, waiting until healthy for 30s"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"healthySince": {
+											"app": "2026-10-18T10:00:00Z"
+										}
+									}
+								}
+							}`),
+						},
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
-								Ready:    fnv1.Ready_READY_TRUE,
 							},
 						},
 					},
 				},
 			},
 		},
-		"CELHealthCheckInlineShouldOverrideHealthCheckFromContext": {
-			reason: "A Configuration should be not ready when inline CEL customization overrides the customization from Context",
+		"MinReadyDurationHealthyLongEnough": {
+			reason: "A composed resource whose composite resource's status says it has been healthy for its minimum ready duration should be ready",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
 			args: args{
 				req: &fnv1.RunFunctionRequest{
-					Meta:    &fnv1.RequestMeta{Tag: "hello"},
-					Context: reqContext,
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
 					Input: resource.MustStructJSON(`{
-						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
 						"kind": "Input",
-						"celHealthCheckCustomization": {
-							"pkg.crossplane.io_v1_Configuration": "false"
-						},
-						"celHealthCheckCustomizationFrom": "[apiextensions.crossplane.io/environment].celHealthCheckCustomizations"
+						"minReadyDuration": "1m"
 					}`),
 					Observed: &fnv1.State{
 						Composite: &fnv1.Resource{
@@ -582,28 +610,28 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 								"kind": "TestXR",
 								"metadata": {
 									"name": "my-test-xr"
+								},
+								"status": {
+									"autoReady": {
+										"healthySince": {
+											"app": "2026-10-18T09:58:00Z"
+										}
+									}
 								}
 							}`),
 						},
 						Resources: map[string]*fnv1.Resource{
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{
-									"apiVersion": "pkg.crossplane.io/v1",
-									"kind": "Configuration",
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
 									"metadata": {
-										"name": "my-configuration"
-									},
-									"spec": {
-										"package": "xpkg.crossplane.io/test-package:0.0.1"
+										"name": "app"
 									},
 									"status": {
 										"conditions": [
 											{
-												"type": "Installed",
-												"status": "True"
-											},
-											{
-												"type": "Healthy",
+												"type": "Ready",
 												"status": "True"
 											}
 										]
@@ -614,11 +642,7 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
 							},
 						},
@@ -627,29 +651,51 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
-					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
-					Context: reqContext,
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"healthySince": {
+											"app": "2026-10-18T09:58:00Z"
+										}
+									}
+								}
+							}`),
+						},
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"my-configuration": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
-								Ready:    fnv1.Ready_READY_FALSE,
+								Ready:    fnv1.Ready_READY_TRUE,
 							},
 						},
 					},
 				},
 			},
 		},
-		"FallbackToReadyCondition": {
-			reason: "Resources without registered health checks should fall back to Ready condition check",
+		"MinReadyDurationOverrideForKind": {
+			reason: "A per-GVK override should take precedence over the minimum ready duration",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
 			args: args{
 				req: &fnv1.RunFunctionRequest{
-					Meta:    &fnv1.RequestMeta{Tag: "hello"},
-					Context: reqContext,
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"minReadyDuration": "1m",
+						"minReadyDurationOverrides": {
+							"test.crossplane.io_v1_TestComposed": "0s"
+						}
+					}`),
 					Observed: &fnv1.State{
 						Composite: &fnv1.Resource{
 							Resource: resource.MustStructJSON(`{
@@ -661,14 +707,13 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 							}`),
 						},
 						Resources: map[string]*fnv1.Resource{
-							"managed-resource": {
+							"app": {
 								Resource: resource.MustStructJSON(`{
-									"apiVersion": "rds.aws.crossplane.io/v1alpha1",
-									"kind": "DBInstance",
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
 									"metadata": {
-										"name": "my-db"
+										"name": "app"
 									},
-									"spec": {},
 									"status": {
 										"conditions": [
 											{
@@ -683,11 +728,7 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 					},
 					Desired: &fnv1.State{
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"managed-resource": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
 							},
 						},
@@ -696,15 +737,29 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 			},
 			want: want{
 				rsp: &fnv1.RunFunctionResponse{
-					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
-					Context: reqContext,
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
 					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"healthySince": {
+											"app": "2026-10-18T10:00:00Z"
+										}
+									}
+								}
+							}`),
+						},
 						Resources: map[string]*fnv1.Resource{
-							// This function doesn't care about the desired
-							// resource schema. In practice it would match
-							// observed (without status), but for this test it
-							// doesn't matter.
-							"managed-resource": {
+							"app": {
 								Resource: resource.MustStructJSON(`{}`),
 								Ready:    fnv1.Ready_READY_TRUE,
 							},
@@ -713,24 +768,4112 @@ func TestCELHealthcheckCustomizations(t *testing.T) {
 				},
 			},
 		},
+		"MinReadyDurationNoLongerHealthy": {
+			reason: "When a composed resource is no longer healthy the time it became healthy should be removed from the composite resource's status",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"minReadyDuration": "1m"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								},
+								"status": {
+									"autoReady": {
+										"healthySince": {
+											"app": "2026-10-18T09:58:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "TestComposed",
+									"metadata": {
+										"name": "app"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "testcomposed app: Ready condition is False: Creating",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: testcomposed app: Ready condition is False: Creating"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"ProgressDeadlineWithinDeadline": {
+			reason: "A composed resource whose composite resource's status says it started progressing recently should still be progressing, and when it started progressing should be persisted again",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"progressDeadline": "30m"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								},
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T09:50:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "example.org/v1",
+									"kind": "Thing",
+									"metadata": {
+										"name": "app",
+										"creationTimestamp": "2026-10-18T09:00:00Z"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating",
+												"lastTransitionTime": "2026-10-18T09:50:00Z"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "thing app: Ready condition is False: Creating",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: thing app: Ready condition is False: Creating"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T09:50:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"ProgressDeadlineStartsWhenFirstProgressing": {
+			reason: "A composed resource seen progressing for the first time should start progressing now, even if it was created and its conditions last transitioned long before its progress deadline",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"progressDeadline": "30m"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web",
+										"generation": 2,
+										"creationTimestamp": "2026-10-01T09:00:00Z"
+									},
+									"spec": {
+										"replicas": 3
+									},
+									"status": {
+										"observedGeneration": 2,
+										"replicas": 3,
+										"updatedReplicas": 1,
+										"availableReplicas": 3,
+										"conditions": [
+											{
+												"type": "Available",
+												"status": "True",
+												"reason": "MinimumReplicasAvailable",
+												"lastTransitionTime": "2026-10-01T09:05:00Z"
+											},
+											{
+												"type": "Progressing",
+												"status": "True",
+												"reason": "ReplicaSetUpdated",
+												"lastTransitionTime": "2026-10-01T09:00:00Z"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "deployment web: 1/3 replicas updated",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: deployment web: 1/3 replicas updated"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"web": "2026-10-18T10:00:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"ProgressDeadlineExceeded": {
+			reason: "A composed resource whose composite resource's status says it has been progressing for longer than its deadline should be degraded, and marked not ready when the unhealthy policy is False",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"progressDeadline": "1h",
+						"unhealthyPolicy": "False"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								},
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T08:30:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "example.org/v1",
+									"kind": "Thing",
+									"metadata": {
+										"name": "app",
+										"creationTimestamp": "2026-10-18T09:00:00Z"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating",
+												"lastTransitionTime": "2026-10-18T09:50:00Z"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_WARNING,
+							Message:  "thing app: progressing for 1h30m0s, exceeded progress deadline of 1h0m0s: Ready condition is False: Creating",
+							Reason:   ptr.To("Degraded"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonDegraded,
+							Message: ptr.To("Composed resources not healthy: thing app: progressing for 1h30m0s, exceeded progress deadline of 1h0m0s: Ready condition is False: Creating"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T08:30:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_FALSE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"ProgressDeadlineOverrideForKind": {
+			reason: "A per-GVK override should take precedence over the progress deadline",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"progressDeadline": "1h",
+						"progressDeadlineOverrides": {
+							"example.org_v1_Thing": "5m"
+						}
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								},
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T09:50:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "example.org/v1",
+									"kind": "Thing",
+									"metadata": {
+										"name": "app",
+										"creationTimestamp": "2026-10-18T09:00:00Z"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating",
+												"lastTransitionTime": "2026-10-18T09:50:00Z"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_WARNING,
+							Message:  "thing app: progressing for 10m0s, exceeded progress deadline of 5m0s: Ready condition is False: Creating",
+							Reason:   ptr.To("Degraded"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonDegraded,
+							Message: ptr.To("Composed resources not healthy: thing app: progressing for 10m0s, exceeded progress deadline of 5m0s: Ready condition is False: Creating"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T09:50:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"ProgressDeadlineNotObserved": {
+			reason: "A composed resource that doesn't exist yet should start progressing now",
+			clock:  func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) },
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"progressDeadline": "1h"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "app: resource has not been created yet",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: app: resource has not been created yet"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"status": {
+									"autoReady": {
+										"progressingSince": {
+											"app": "2026-10-18T10:00:00Z"
+										}
+									}
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"app": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckResourceOverridesDisabled": {
+			reason: "CEL health check resource overrides should be ignored with a warning when the CELHealthcheckCustomizations alpha feature is disabled",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"celHealthCheckResourceOverrides": {
+							"web": "false"
+						}
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web"
+									},
+									"spec": {
+										"replicas": 1
+									},
+									"status": {
+										"updatedReplicas": 1,
+										"availableReplicas": 1,
+										"conditions": [
+											{
+												"type": "Available",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_WARNING,
+							Message:  "ignoring CEL health check resource overrides: the CELHealthcheckCustomizations alpha feature is not enabled",
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckRulesDisabled": {
+			reason: "CEL health check rules should be ignored with a warning when the CELHealthcheckCustomizations alpha feature is disabled",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"celHealthCheckRules": [
+							{
+								"group": "apps",
+								"kind": "Deployment",
+								"expression": "false"
+							}
+						]
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web"
+									},
+									"spec": {
+										"replicas": 1
+									},
+									"status": {
+										"updatedReplicas": 1,
+										"availableReplicas": 1,
+										"conditions": [
+											{
+												"type": "Available",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_WARNING,
+							Message:  "ignoring CEL health check rules: the CELHealthcheckCustomizations alpha feature is not enabled",
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): false,
+				string(features.LuaHealthcheckCustomizations): false,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL, clock: tc.clock}
+			rsp, err := f.RunFunction(tc.args.ctx, tc.args.req)
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRunFunctionCacheTTL(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"},"spec":{"count":1}}`
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   *fnv1.RunFunctionResponse
+	}{
+		"InputTTL": {
+			reason: "Set the response ttl value from the input specified",
+			input:  &v1beta1.Input{TTL: "5m"},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(5 * time.Minute)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "xr second: resource has not been created yet",
+						Reason:   ptr.To("Progressing"),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					{
+						Type:    ConditionTypeResourcesHealthy,
+						Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+						Reason:  ReasonProgressing,
+						Message: ptr.To("Composed resources not healthy: xr second: resource has not been created yet"),
+						Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"second": {
+							Resource: resource.MustStructJSON(xr),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger()}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{},
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"second": {
+							Resource: resource.MustStructJSON(xr),
+						},
+					},
+				},
+			}
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRunFunctionStatusRoundTrip(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	ready := `{"apiVersion": "example.org/v1", "kind": "Thing", "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`
+	creating := `{"apiVersion": "example.org/v1", "kind": "Thing", "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Creating"}]}}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		interval time.Duration
+		observed []string
+		want     []fnv1.Ready
+	}{
+		"MinReadyDuration": {
+			reason:   "When a composed resource became healthy should be read back from the observed status of the composite resource, so it's ready once it has been healthy for its minimum ready duration",
+			input:    &v1beta1.Input{MinReadyDuration: "1m"},
+			interval: 40 * time.Second,
+			observed: []string{ready, ready, ready},
+			want:     []fnv1.Ready{fnv1.Ready_READY_UNSPECIFIED, fnv1.Ready_READY_UNSPECIFIED, fnv1.Ready_READY_TRUE},
+		},
+		"ProgressDeadline": {
+			reason:   "When a composed resource started progressing should be read back from the observed status of the composite resource, so it's degraded once it has been progressing for longer than its deadline",
+			input:    &v1beta1.Input{ProgressDeadline: "1m", UnhealthyPolicy: v1beta1.UnhealthyPolicyFalse},
+			interval: 40 * time.Second,
+			observed: []string{creating, creating, creating},
+			want:     []fnv1.Ready{fnv1.Ready_READY_UNSPECIFIED, fnv1.Ready_READY_UNSPECIFIED, fnv1.Ready_READY_FALSE},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL, clock: func() time.Time { return now }}
+
+			// Each call observes the composite resource status the previous
+			// call desired, like it would after Crossplane applied it.
+			oxr := resource.MustStructJSON(xr)
+			for i, o := range tc.observed {
+				req := &fnv1.RunFunctionRequest{
+					Input: resource.MustStructObject(tc.input),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{Resource: oxr},
+						Resources: map[string]*fnv1.Resource{
+							"app": {Resource: resource.MustStructJSON(o)},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"app": {Resource: resource.MustStructJSON(`{}`)},
+						},
+					},
+				}
+				rsp, err := f.RunFunction(context.Background(), req)
+				if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+					t.Fatalf("%s\nf.RunFunction(...) call %d: -want err, +got err:\n%s", tc.reason, i, diff)
+				}
+				if diff := cmp.Diff(tc.want[i], rsp.GetDesired().GetResources()["app"].GetReady()); diff != "" {
+					t.Errorf("%s\nf.RunFunction(...) call %d: -want ready, +got ready:\n%s", tc.reason, i, diff)
+				}
+
+				oxr = resource.MustStructJSON(xr)
+				if status, ok := rsp.GetDesired().GetComposite().GetResource().GetFields()["status"]; ok {
+					oxr.Fields["status"] = status
+				}
+				now = now.Add(tc.interval)
+			}
+		})
+	}
+}
+
+func TestCELHealthcheckCustomizations(t *testing.T) {
+	reqContext := resource.MustStructJSON(`{
+		"apiextensions.crossplane.io/environment": {
+			"celHealthCheckCustomizations": {
+				"pkg.crossplane.io_v1_Configuration": "object.status.conditions.exists(c, c.type == 'Installed' && c.status == 'True') && object.status.conditions.exists(c, c.type == 'Healthy' && c.status == 'True')"
+			}
+		}
+	}`)
+
+	type args struct {
+		ctx context.Context
+		req *fnv1.RunFunctionRequest
+	}
+	type want struct {
+		rsp *fnv1.RunFunctionResponse
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"CELHealthCheckFromContext": {
+			reason: "A Configuration should be ready when both conditions are true and CEL customization in context is present",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta:    &fnv1.RequestMeta{Tag: "hello"},
+					Context: reqContext,
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"kind": "Input",
+						"celHealthCheckCustomizationFrom": "[apiextensions.crossplane.io/environment].celHealthCheckCustomizations"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "my-configuration"
+									},
+									"spec": {
+										"package": "xpkg.crossplane.io/test-package:0.0.1"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Installed",
+												"status": "True"
+											},
+											{
+												"type": "Healthy",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Context: reqContext,
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckFromInput": {
+			reason: "A Configuration should be ready when both conditions are true and CEL customization in Input is present",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"kind": "Input",
+						"celHealthCheckCustomization": {
+							"pkg.crossplane.io_v1_Configuration": "object.status.conditions.exists(c, c.type == 'Installed' && c.status == 'True') && object.status.conditions.exists(c, c.type == 'Healthy' && c.status == 'True')"
+						}
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "my-configuration"
+									},
+									"spec": {
+										"package": "xpkg.crossplane.io/test-package:0.0.1"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Installed",
+												"status": "True"
+											},
+											{
+												"type": "Healthy",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckInlineShouldOverrideHealthCheckFromContext": {
+			reason: "A Configuration should be not ready when inline CEL customization overrides the customization from Context",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta:    &fnv1.RequestMeta{Tag: "hello"},
+					Context: reqContext,
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"kind": "Input",
+						"celHealthCheckCustomization": {
+							"pkg.crossplane.io_v1_Configuration": "false"
+						},
+						"celHealthCheckCustomizationFrom": "[apiextensions.crossplane.io/environment].celHealthCheckCustomizations"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "my-configuration"
+									},
+									"spec": {
+										"package": "xpkg.crossplane.io/test-package:0.0.1"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Installed",
+												"status": "True"
+											},
+											{
+												"type": "Healthy",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Context: reqContext,
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_WARNING,
+							Message:  "configuration my-configuration: health check expression evaluated to false",
+							Reason:   ptr.To("Degraded"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonDegraded,
+							Message: ptr.To("Composed resources not healthy: configuration my-configuration: health check expression evaluated to false"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_FALSE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckStructuredResult": {
+			reason: "A CEL customization returning a map should report its status and message",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta:    &fnv1.RequestMeta{Tag: "hello"},
+					Context: reqContext,
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1alpha1",
+						"kind": "Input",
+						"celHealthCheckCustomization": {
+							"pkg.crossplane.io_v1_Configuration": "object.status.conditions.exists(c, c.type == 'Healthy' && c.status == 'True') ? {'status': 'Healthy'} : {'status': 'Progressing', 'message': 'waiting for package ' + object.spec.package}"
+						}
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "my-configuration"
+									},
+									"spec": {
+										"package": "xpkg.crossplane.io/test-package:0.0.1"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Installed",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Context: reqContext,
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "configuration my-configuration: waiting for package xpkg.crossplane.io/test-package:0.0.1",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: configuration my-configuration: waiting for package xpkg.crossplane.io/test-package:0.0.1"),
+							Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"FallbackToReadyCondition": {
+			reason: "Resources without registered health checks should fall back to Ready condition check",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta:    &fnv1.RequestMeta{Tag: "hello"},
+					Context: reqContext,
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"managed-resource": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "rds.aws.crossplane.io/v1alpha1",
+									"kind": "DBInstance",
+									"metadata": {
+										"name": "my-db"
+									},
+									"spec": {},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "True"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"managed-resource": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta:    &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Context: reqContext,
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							// This function doesn't care about the desired
+							// resource schema. In practice it would match
+							// observed (without status), but for this test it
+							// doesn't matter.
+							"managed-resource": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckSelectorsHonoured": {
+			reason: "Only composed resources selected by include and not matched by exclude should be evaluated by CEL health checks, others should pass through untouched",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"include": [{"kind": "Configuration"}],
+						"exclude": [{"matchLabels": {"autoready": "skip"}}],
+						"celHealthCheckCustomization": {
+							"pkg.crossplane.io_v1_Configuration": "true",
+							"s3.aws.upbound.io_v1beta1_Bucket": "true"
+						}
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "my-configuration"
+									}
+								}`),
+							},
+							"skipped-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "pkg.crossplane.io/v1",
+									"kind": "Configuration",
+									"metadata": {
+										"name": "skipped-configuration"
+									}
+								}`),
+							},
+							"bucket": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "s3.aws.upbound.io/v1beta1",
+									"kind": "Bucket",
+									"metadata": {
+										"name": "my-bucket"
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+							"skipped-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"metadata": {
+										"labels": {
+											"autoready": "skip"
+										}
+									}
+								}`),
+							},
+							"bucket": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"my-configuration": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+							"skipped-configuration": {
+								Resource: resource.MustStructJSON(`{
+									"metadata": {
+										"labels": {
+											"autoready": "skip"
+										}
+									}
+								}`),
+							},
+							"bucket": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"CELHealthCheckResourceOverrideBeatsPrioritisedRule": {
+			reason: "A CEL health check resource override should take precedence over a rule with a priority that matches the same composed resource",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"celHealthCheckResourceOverrides": {
+							"web": "object.status.availableReplicas >= 1"
+						},
+						"celHealthCheckRules": [
+							{
+								"name": "all-replicas",
+								"group": "apps",
+								"kind": "Deployment",
+								"expression": "object.status.availableReplicas == object.spec.replicas",
+								"priority": 10
+							}
+						]
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web"
+									},
+									"spec": {
+										"replicas": 3
+									},
+									"status": {
+										"updatedReplicas": 3,
+										"availableReplicas": 1
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:   ConditionTypeResourcesHealthy,
+							Status: fnv1.Status_STATUS_CONDITION_TRUE,
+							Reason: ReasonAllHealthy,
+							Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+								Ready:    fnv1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): true,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			rsp, err := f.RunFunction(tc.args.ctx, tc.args.req)
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthReporting(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req *fnv1.RunFunctionRequest
+	}
+	type want struct {
+		rsp *fnv1.RunFunctionResponse
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ProgressingAndDegradedResources": {
+			reason: "Progressing resources should produce Normal results, degraded resources Warning results, and both should be summarised in the ResourcesHealthy condition",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "apps/v1",
+									"kind": "Deployment",
+									"metadata": {
+										"name": "web"
+									},
+									"spec": {
+										"replicas": 3
+									},
+									"status": {
+										"updatedReplicas": 3,
+										"availableReplicas": 2
+									}
+								}`),
+							},
+							"worker": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "v1",
+									"kind": "Pod",
+									"metadata": {
+										"name": "worker"
+									},
+									"spec": {
+										"restartPolicy": "Always"
+									},
+									"status": {
+										"phase": "Running",
+										"containerStatuses": [
+											{
+												"name": "worker",
+												"state": {
+													"waiting": {
+														"reason": "CrashLoopBackOff"
+													}
+												}
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"web": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+							"worker": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						progressingResult("deployment web: 2/3 replicas available"),
+						degradedResult("pod worker: container worker: CrashLoopBackOff"),
+					},
+					Conditions: []*fnv1.Condition{
+						unhealthyCondition(ReasonDegraded, "deployment web: 2/3 replicas available", "pod worker: container worker: CrashLoopBackOff"),
+					},
+					Desired: &fnv1.State{
+						Resources: desiredResources(map[string]fnv1.Ready{
+							"web":    fnv1.Ready_READY_UNSPECIFIED,
+							"worker": fnv1.Ready_READY_UNSPECIFIED,
+						}),
+					},
+				},
+			},
+		},
+		"TargetCompositeAndClaim": {
+			reason: "Results and conditions should target the claim too when requested, and a Ready condition that is not True should be explained",
+			args: args{
+				req: &fnv1.RunFunctionRequest{
+					Meta: &fnv1.RequestMeta{Tag: "hello"},
+					Input: resource.MustStructJSON(`{
+						"apiVersion": "autoready.fn.crossplane.io/v1beta1",
+						"kind": "Input",
+						"resultTarget": "CompositeAndClaim"
+					}`),
+					Observed: &fnv1.State{
+						Composite: &fnv1.Resource{
+							Resource: resource.MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "TestXR",
+								"metadata": {
+									"name": "my-test-xr"
+								}
+							}`),
+						},
+						Resources: map[string]*fnv1.Resource{
+							"bucket": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "s3.aws.upbound.io/v1beta1",
+									"kind": "Bucket",
+									"metadata": {
+										"name": "my-bucket"
+									},
+									"status": {
+										"conditions": [
+											{
+												"type": "Ready",
+												"status": "False",
+												"reason": "Creating"
+											}
+										]
+									}
+								}`),
+							},
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"bucket": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				rsp: &fnv1.RunFunctionResponse{
+					Meta: &fnv1.ResponseMeta{Tag: "hello", Ttl: durationpb.New(response.DefaultTTL)},
+					Results: []*fnv1.Result{
+						{
+							Severity: fnv1.Severity_SEVERITY_NORMAL,
+							Message:  "bucket bucket: Ready condition is False: Creating",
+							Reason:   ptr.To("Progressing"),
+							Target:   fnv1.Target_TARGET_COMPOSITE_AND_CLAIM.Enum(),
+						},
+					},
+					Conditions: []*fnv1.Condition{
+						{
+							Type:    ConditionTypeResourcesHealthy,
+							Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+							Reason:  ReasonProgressing,
+							Message: ptr.To("Composed resources not healthy: bucket bucket: Ready condition is False: Creating"),
+							Target:  fnv1.Target_TARGET_COMPOSITE_AND_CLAIM.Enum(),
+						},
+					},
+					Desired: &fnv1.State{
+						Resources: map[string]*fnv1.Resource{
+							"bucket": {
+								Resource: resource.MustStructJSON(`{}`),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			rsp, err := f.RunFunction(tc.args.ctx, tc.args.req)
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnhealthyPolicy(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	crashingPod := `{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "worker"},
+		"spec": {"restartPolicy": "Always"},
+		"status": {
+			"phase": "Running",
+			"containerStatuses": [{"name": "worker", "state": {"waiting": {"reason": "CrashLoopBackOff"}}}]
+		}
+	}`
+	pendingPod := `{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "worker"},
+		"spec": {"restartPolicy": "Always"},
+		"status": {"phase": "Pending"}
+	}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		observed string
+		want     *fnv1.RunFunctionResponse
+	}{
+		"DegradedWithDefaultPolicy": {
+			reason:   "A degraded resource should be left with unspecified readiness by default",
+			input:    &v1beta1.Input{},
+			observed: crashingPod,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("pod worker: container worker: CrashLoopBackOff"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "pod worker: container worker: CrashLoopBackOff"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"DegradedWithFalsePolicy": {
+			reason:   "A degraded resource should be marked not ready when the unhealthy policy is False",
+			input:    &v1beta1.Input{UnhealthyPolicy: v1beta1.UnhealthyPolicyFalse},
+			observed: crashingPod,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("pod worker: container worker: CrashLoopBackOff"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "pod worker: container worker: CrashLoopBackOff"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"worker": fnv1.Ready_READY_FALSE,
+					}),
+				},
+			},
+		},
+		"ProgressingWithFalsePolicy": {
+			reason:   "A progressing resource should be left with unspecified readiness even when the unhealthy policy is False",
+			input:    &v1beta1.Input{UnhealthyPolicy: v1beta1.UnhealthyPolicyFalse},
+			observed: pendingPod,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("pod worker: pod is pending"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "pod worker: pod is pending"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"worker": {
+							Resource: resource.MustStructJSON(tc.observed),
+						},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"worker": {
+							Resource: resource.MustStructJSON(`{}`),
+						},
+					},
+				},
+			}
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOptionalResources(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	pendingPod := `{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "dashboard"},
+		"spec": {"restartPolicy": "Always"},
+		"status": {"phase": "Pending"}
+	}`
+	annotatedPod := `{"apiVersion": "v1", "kind": "Pod", "metadata": {"annotations": {"autoready.fn.crossplane.io/optional": "true"}}}`
+	pod := `{"apiVersion": "v1", "kind": "Pod"}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		desired  string
+		observed map[string]*fnv1.Resource
+		want     *fnv1.RunFunctionResponse
+	}{
+		"AnnotatedOptionalResource": {
+			reason:  "An observed composed resource annotated as optional should be marked ready, with its true health reported as a Warning",
+			input:   &v1beta1.Input{},
+			desired: annotatedPod,
+			observed: map[string]*fnv1.Resource{
+				"dashboard": {Resource: resource.MustStructJSON(pendingPod)},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_WARNING,
+						Message:  "pod dashboard: pod is pending (optional)",
+						Reason:   ptr.To("Progressing"),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "pod dashboard: pod is pending (optional)"),
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"dashboard": {
+							Resource: resource.MustStructJSON(annotatedPod),
+							Ready:    fnv1.Ready_READY_TRUE,
+						},
+					},
+				},
+			},
+		},
+		"SelectedOptionalResourceNotObserved": {
+			reason:  "An optional composed resource that doesn't exist yet should not be marked ready by default",
+			input:   &v1beta1.Input{OptionalResources: []v1beta1.ResourceSelector{{Name: "dash*"}}},
+			desired: pod,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_WARNING,
+						Message:  "pod dashboard: resource has not been created yet (optional)",
+						Reason:   ptr.To("Progressing"),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "pod dashboard: resource has not been created yet (optional)"),
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"dashboard": {
+							Resource: resource.MustStructJSON(pod),
+						},
+					},
+				},
+			},
+		},
+		"SelectedOptionalResourceAlwaysReady": {
+			reason: "An optional composed resource that doesn't exist yet should be marked ready when the policy is Always",
+			input: &v1beta1.Input{
+				OptionalResources:      []v1beta1.ResourceSelector{{Name: "dash*"}},
+				OptionalResourcePolicy: v1beta1.OptionalResourcePolicyAlways,
+			},
+			desired: pod,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_WARNING,
+						Message:  "pod dashboard: resource has not been created yet (optional)",
+						Reason:   ptr.To("Progressing"),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "pod dashboard: resource has not been created yet (optional)"),
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"dashboard": {
+							Resource: resource.MustStructJSON(pod),
+							Ready:    fnv1.Ready_READY_TRUE,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: tc.observed,
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"dashboard": {
+							Resource: resource.MustStructJSON(tc.desired),
+						},
+					},
+				},
+			}
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	ready := `{"apiVersion": "example.org/v1", "kind": "Thing", "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`
+	notReady := `{"apiVersion": "example.org/v1", "kind": "Thing", "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Creating"}]}}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		observed map[string]string
+		want     *fnv1.RunFunctionResponse
+	}{
+		"DependencyReady": {
+			reason: "A healthy composed resource should be ready when its dependency is ready",
+			input: &v1beta1.Input{Dependencies: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret"}},
+			}},
+			observed: map[string]string{"app": ready, "secret": ready, "database": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":      fnv1.Ready_READY_TRUE,
+						"database": fnv1.Ready_READY_TRUE,
+						"secret":   fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"TransitiveDependencyNotReady": {
+			reason: "A healthy composed resource should not be ready, and should be reported as progressing, while a transitive dependency is not ready",
+			input: &v1beta1.Input{Dependencies: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret"}},
+				{Resource: "secret", DependsOn: []string{"database"}},
+			}},
+			observed: map[string]string{"app": ready, "secret": ready, "database": notReady},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("thing app: waiting for dependency secret to be ready"),
+					progressingResult("thing database: Ready condition is False: Creating"),
+					progressingResult("thing secret: waiting for dependency database to be ready"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing,
+						"thing app: waiting for dependency secret to be ready",
+						"thing database: Ready condition is False: Creating",
+						"thing secret: waiting for dependency database to be ready",
+					),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":      fnv1.Ready_READY_UNSPECIFIED,
+						"database": fnv1.Ready_READY_UNSPECIFIED,
+						"secret":   fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"Cycle": {
+			reason: "A dependency cycle should return a fatal result",
+			input: &v1beta1.Input{Dependencies: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"secret"}},
+				{Resource: "secret", DependsOn: []string{"app"}},
+			}},
+			observed: map[string]string{"app": ready, "secret": ready, "database": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult("cannot apply composed resource dependencies: dependency cycle: app -> secret -> app"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":      fnv1.Ready_READY_UNSPECIFIED,
+						"database": fnv1.Ready_READY_UNSPECIFIED,
+						"secret":   fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"UnknownDependency": {
+			reason: "A dependency on a composed resource that isn't desired should return a fatal result",
+			input: &v1beta1.Input{Dependencies: []v1beta1.Dependency{
+				{Resource: "app", DependsOn: []string{"sercet"}},
+			}},
+			observed: map[string]string{"app": ready, "secret": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult(`cannot apply composed resource dependencies: dependencies[0].dependsOn[0]: unknown composed resource "sercet"`),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":    fnv1.Ready_READY_UNSPECIFIED,
+						"secret": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{},
+				},
+			}
+			for n, o := range tc.observed {
+				req.Observed.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(o)}
+				req.Desired.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(`{}`)}
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadinessTTL(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	ready := `{"apiVersion":"example.org/v1","kind":"Thing","status":{"conditions":[{"type":"Ready","status":"True"}]}}`
+	creating := `{"apiVersion":"example.org/v1","kind":"Thing","status":{"conditions":[{"type":"Ready","status":"False","reason":"Creating"}]}}`
+	bucket := `{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","status":{"conditions":[{"type":"Ready","status":"False","reason":"Creating"}]}}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		status   map[string]any
+		observed map[string]*fnv1.Resource
+		want     *fnv1.RunFunctionResponse
+	}{
+		"NotConfigured": {
+			reason:   "The TTL should be unchanged when readiness-aware TTLs aren't configured",
+			input:    &v1beta1.Input{TTL: "5m"},
+			observed: map[string]*fnv1.Resource{"app": {Resource: resource.MustStructJSON(creating)}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(5 * time.Minute)},
+				Results: []*fnv1.Result{
+					progressingResult("thing app: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "thing app: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"AllReady": {
+			reason: "The ready TTL should be used when every composed resource is ready",
+			input:  &v1beta1.Input{TTL: "5m", ReadyTTL: "1h", ProgressingTTL: "10s"},
+			observed: map[string]*fnv1.Resource{
+				"app":    {Resource: resource.MustStructJSON(ready)},
+				"bucket": {Resource: resource.MustStructJSON(ready)},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(1 * time.Hour)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":    fnv1.Ready_READY_TRUE,
+						"bucket": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"Progressing": {
+			reason: "The progressing TTL should be used when any composed resource is not ready",
+			input:  &v1beta1.Input{TTL: "5m", ReadyTTL: "1h", ProgressingTTL: "10s"},
+			observed: map[string]*fnv1.Resource{
+				"app":    {Resource: resource.MustStructJSON(ready)},
+				"bucket": {Resource: resource.MustStructJSON(creating)},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(10 * time.Second)},
+				Results: []*fnv1.Result{
+					progressingResult("thing bucket: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "thing bucket: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":    fnv1.Ready_READY_TRUE,
+						"bucket": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"ProgressingOnlyReady": {
+			reason:   "The TTL should be unchanged when every composed resource is ready and only a progressing TTL is configured",
+			input:    &v1beta1.Input{TTL: "5m", ProgressingTTL: "10s"},
+			observed: map[string]*fnv1.Resource{"app": {Resource: resource.MustStructJSON(ready)}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(5 * time.Minute)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"ShortestOverride": {
+			reason: "The shortest progressing TTL of any composed resource that is not ready should be used",
+			input: &v1beta1.Input{
+				ProgressingTTL:          "1m",
+				ProgressingTTLOverrides: map[string]string{"s3.aws.upbound.io_v1beta1_Bucket": "5m", "example.org_v1_Thing": "20s"},
+			},
+			observed: map[string]*fnv1.Resource{
+				"app":    {Resource: resource.MustStructJSON(creating)},
+				"bucket": {Resource: resource.MustStructJSON(bucket)},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(20 * time.Second)},
+				Results: []*fnv1.Result{
+					progressingResult("thing app: Ready condition is False: Creating"),
+					progressingResult("bucket bucket: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "thing app: Ready condition is False: Creating", "bucket bucket: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app":    fnv1.Ready_READY_UNSPECIFIED,
+						"bucket": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"MinReadyDurationDue": {
+			reason:   "The TTL should be shortened so the function is called again when a minimum ready duration elapses",
+			input:    &v1beta1.Input{ProgressingTTL: "1h", MinReadyDuration: "2m"},
+			status:   map[string]any{"autoReady": map[string]any{"healthySince": map[string]any{"app": "2026-10-18T09:59:30Z"}}},
+			observed: map[string]*fnv1.Resource{"app": {Resource: resource.MustStructJSON(ready)}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(90 * time.Second)},
+				Results: []*fnv1.Result{
+					progressingResult("thing app: healthy for 30s, waiting until healthy for 2m0s"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "thing app: healthy for 30s, waiting until healthy for 2m0s"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(`{
+							"status": {
+								"autoReady": {
+									"healthySince": {
+										"app": "2026-10-18T09:59:30Z"
+									}
+								}
+							}
+						}`),
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"app": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := composite.New()
+			xr.SetAPIVersion("example.org/v1")
+			xr.SetKind("XR")
+			xr.SetName("cool-xr")
+			if tc.status != nil {
+				_ = xr.SetValue("status", tc.status)
+			}
+
+			desired := make(map[string]*fnv1.Resource, len(tc.observed))
+			for name := range tc.observed {
+				desired[name] = &fnv1.Resource{Resource: resource.MustStructJSON(`{}`)}
+			}
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL, clock: func() time.Time { return now }}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructObject(xr),
+					},
+					Resources: tc.observed,
+				},
+				Desired: &fnv1.State{Resources: desired},
+			}
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompositeReadiness(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"},"status":{"endpoint":"https://example.org"}}`
+	ready := `{"apiVersion": "example.org/v1", "kind": "Replica", "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`
+	notReady := `{"apiVersion": "example.org/v1", "kind": "Replica", "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Creating"}]}}`
+
+	replicas := map[string]string{"region-a": ready, "region-b": ready, "region-c": notReady, "dns": ready}
+
+	cases := map[string]struct {
+		reason     string
+		input      *v1beta1.Input
+		celEnabled bool
+		xrReady    fnv1.Ready
+		observed   map[string]string
+		want       *fnv1.RunFunctionResponse
+	}{
+		"NotConfigured": {
+			reason:   "The readiness of the composite resource should be left unspecified when no policy is configured",
+			input:    &v1beta1.Input{},
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"AllNotReady": {
+			reason:   "The composite resource should not be ready when any composed resource isn't ready under the All policy",
+			input:    &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{Policy: v1beta1.CompositeReadinessPolicyAll}},
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "composite resource not ready: 3/4 composed resources ready, need 4",
+						Reason:   ptr.To(ReasonCompositeNotReady),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_FALSE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"Any": {
+			reason: "The composite resource should be ready when any selected composed resource is ready under the Any policy",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Policy:    v1beta1.CompositeReadinessPolicyAny,
+				Resources: []v1beta1.ResourceSelector{{Name: "region-*"}},
+			}},
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_TRUE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"MinReadyMet": {
+			reason: "The composite resource should be ready when at least MinReady selected composed resources are ready",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Policy:    v1beta1.CompositeReadinessPolicyMinReady,
+				MinReady:  ptr.To(2),
+				Resources: []v1beta1.ResourceSelector{{Name: "region-*"}},
+			}},
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_TRUE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"MinReadyNotMet": {
+			reason: "The composite resource should not be ready when fewer than MinReady selected composed resources are ready",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Policy:    v1beta1.CompositeReadinessPolicyMinReady,
+				MinReady:  ptr.To(3),
+				Resources: []v1beta1.ResourceSelector{{Name: "region-*"}},
+			}},
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "composite resource not ready: 2/3 composed resources ready, need 3",
+						Reason:   ptr.To(ReasonCompositeNotReady),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_FALSE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"MinReadyMissing": {
+			reason:   "The MinReady policy without a MinReady count should return a fatal result",
+			input:    &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{Policy: v1beta1.CompositeReadinessPolicyMinReady}},
+			observed: map[string]string{"region-a": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult("cannot determine composite resource readiness: minReady must be at least 1 when policy is MinReady"),
+				},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"ExpressionTrue": {
+			reason: "The composite resource should be ready when the policy is met and its expression evaluates to true",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Expression: `has(object.status.endpoint)`,
+			}},
+			celEnabled: true,
+			observed:   map[string]string{"region-a": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_TRUE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"ExpressionFalse": {
+			reason: "The composite resource should not be ready when its expression evaluates to false",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Expression: `object.status.endpoint == ""`,
+			}},
+			celEnabled: true,
+			observed:   map[string]string{"region-a": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "composite resource not ready: composite readiness expression evaluated to false",
+						Reason:   ptr.To(ReasonCompositeNotReady),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+				},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_FALSE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"ExpressionFeatureDisabled": {
+			reason: "The expression should be ignored with a warning when the CEL feature is disabled",
+			input: &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{
+				Expression: `object.status.endpoint == ""`,
+			}},
+			observed: map[string]string{"region-a": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult("ignoring composite readiness expression: the CELHealthcheckCustomizations alpha feature is not enabled"),
+				},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_TRUE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"ExplicitReadiness": {
+			reason:   "The readiness of the composite resource should not change if a previous Function set it",
+			input:    &v1beta1.Input{CompositeReadiness: &v1beta1.CompositeReadiness{}},
+			xrReady:  fnv1.Ready_READY_TRUE,
+			observed: replicas,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("replica region-c: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-c: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    fnv1.Ready_READY_TRUE,
+					},
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_TRUE,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): tc.celEnabled,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{},
+				},
+				Desired: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+						Ready:    tc.xrReady,
+					},
+					Resources: map[string]*fnv1.Resource{},
+				},
+			}
+			for n, o := range tc.observed {
+				req.Observed.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(o)}
+				req.Desired.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(`{}`)}
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadinessGroups(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	ready := `{"apiVersion": "example.org/v1", "kind": "Replica", "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`
+	notReady := `{"apiVersion": "example.org/v1", "kind": "Replica", "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Creating"}]}}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		observed map[string]string
+		want     *fnv1.RunFunctionResponse
+	}{
+		"QuorumReached": {
+			reason: "Every member of a group should be ready once a quorum of members is healthy",
+			input: &v1beta1.Input{ReadinessGroups: []v1beta1.ReadinessGroup{
+				{Name: "regions", Resources: []v1beta1.ResourceSelector{{Name: "region-*"}}, MinReady: 2},
+			}},
+			observed: map[string]string{"region-a": ready, "region-b": notReady, "region-c": ready, "dns": notReady},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "readiness group regions: 2/3 members healthy (region-a, region-c), marking all members ready",
+						Reason:   ptr.To(ReasonQuorumReached),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+					progressingResult("replica dns: Ready condition is False: Creating"),
+					progressingResult("replica region-b: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica dns: Ready condition is False: Creating", "replica region-b: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"dns":      fnv1.Ready_READY_UNSPECIFIED,
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_TRUE,
+						"region-c": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"QuorumNotReached": {
+			reason: "Members of a group should keep their own readiness until a quorum of members is healthy",
+			input: &v1beta1.Input{ReadinessGroups: []v1beta1.ReadinessGroup{
+				{Name: "replicas", Resources: []v1beta1.ResourceSelector{{Kind: "Replica"}}, MinReady: 2},
+			}},
+			observed: map[string]string{"region-a": ready, "region-b": notReady},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					{
+						Severity: fnv1.Severity_SEVERITY_NORMAL,
+						Message:  "readiness group replicas: 1/2 members healthy (region-a), need 2",
+						Reason:   ptr.To(ReasonQuorumNotReached),
+						Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+					},
+					progressingResult("replica region-b: Ready condition is False: Creating"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "replica region-b: Ready condition is False: Creating"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_TRUE,
+						"region-b": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"InvalidMinReady": {
+			reason: "A group with a minReady less than one should return a fatal result",
+			input: &v1beta1.Input{ReadinessGroups: []v1beta1.ReadinessGroup{
+				{Name: "regions", Resources: []v1beta1.ResourceSelector{{Name: "region-*"}}},
+			}},
+			observed: map[string]string{"region-a": ready},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult(`cannot apply readiness groups: readiness group "regions" minReady must be at least 1`),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"region-a": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{},
+				},
+			}
+			for n, o := range tc.observed {
+				req.Observed.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(o)}
+				req.Desired.Resources[n] = &fnv1.Resource{Resource: resource.MustStructJSON(`{}`)}
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCELValidation(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	thing := `{"apiVersion": "example.org/v1", "kind": "Thing", "status": {"phase": "Running"}}`
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   *fnv1.RunFunctionResponse
+	}{
+		"Warning": {
+			reason: "Invalid customizations should be reported in a single Warning result and ignored",
+			input: &v1beta1.Input{CELHealthCheckCustomization: &map[string]string{
+				"example.org_v1_Thing": `object.status.phase == 'Running'`,
+				"example.org_v1_Other": `object.status.phase`,
+				"Thing":                `true`,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult("invalid CEL health check customizations: Thing: key must be of the form <group>_<version>_<kind>, where the version and kind may be *; example.org_v1_Other: celQuery does not return a bool or map type"),
+				},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"thing": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"Fatal": {
+			reason: "Invalid customizations should return a Fatal result when the validation policy is Fatal",
+			input: &v1beta1.Input{
+				CELValidationPolicy: v1beta1.CELValidationPolicyFatal,
+				CELHealthCheckCustomization: &map[string]string{
+					"example.org_v1_Thing": `object.status.phase == 'Running'`,
+					"example.org_v1_Other": `object.status.phase`,
+				},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult("invalid CEL health check customizations: example.org_v1_Other: celQuery does not return a bool or map type"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"thing": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): true,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"thing": {Resource: resource.MustStructJSON(thing)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"thing": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCELLimits(t *testing.T) {
+	type want struct {
+		costLimit uint64
+		timeout   time.Duration
+		err       bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		f      *Function
+		input  *v1beta1.Input
+		want   want
+	}{
+		"Ceilings": {
+			reason: "The Function's ceilings should be used when the input doesn't set limits",
+			f:      &Function{celCostLimit: 1000, celTimeout: time.Second},
+			input:  &v1beta1.Input{},
+			want:   want{costLimit: 1000, timeout: time.Second},
+		},
+		"Lower": {
+			reason: "The input should be able to lower the limits",
+			f:      &Function{celCostLimit: 1000, celTimeout: time.Second},
+			input:  &v1beta1.Input{CELCostLimit: ptr.To[int64](10), CELEvaluationTimeout: "10ms"},
+			want:   want{costLimit: 10, timeout: 10 * time.Millisecond},
+		},
+		"Clamped": {
+			reason: "The input shouldn't be able to exceed the Function's ceilings",
+			f:      &Function{celCostLimit: 1000, celTimeout: time.Second},
+			input:  &v1beta1.Input{CELCostLimit: ptr.To[int64](1000000), CELEvaluationTimeout: "1m"},
+			want:   want{costLimit: 1000, timeout: time.Second},
+		},
+		"NoCeilings": {
+			reason: "The input's limits should be used when the Function has no ceilings",
+			f:      &Function{},
+			input:  &v1beta1.Input{CELCostLimit: ptr.To[int64](1000000), CELEvaluationTimeout: "1m"},
+			want:   want{costLimit: 1000000, timeout: time.Minute},
+		},
+		"InvalidTimeout": {
+			reason: "An invalid timeout should return an error",
+			f:      &Function{},
+			input:  &v1beta1.Input{CELEvaluationTimeout: "soon"},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			costLimit, timeout, err := tc.f.celLimits(tc.input)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nf.celLimits(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.costLimit, costLimit); diff != "" {
+				t.Errorf("%s\nf.celLimits(...): -want cost limit, +got cost limit:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.timeout, timeout); diff != "" {
+				t.Errorf("%s\nf.celLimits(...): -want timeout, +got timeout:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCELResourceOverrides(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	deployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"spec": {"replicas": 3},
+		"status": {"updatedReplicas": 3, "availableReplicas": 1, "conditions": [{"type": "Available", "status": "True"}]}
+	}`
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   *fnv1.RunFunctionResponse
+	}{
+		"OverridesBuiltIn": {
+			reason: "A resource override should take precedence over the built-in health check",
+			input: &v1beta1.Input{CELHealthCheckResourceOverrides: map[string]string{
+				"worker": `object.status.availableReplicas >= 1`,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("deployment web: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment web: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_UNSPECIFIED,
+						"worker": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"OverridesGVKCustomization": {
+			reason: "A resource override should take precedence over a customization keyed by GVK",
+			input: &v1beta1.Input{
+				UnhealthyPolicy:                 v1beta1.UnhealthyPolicyFalse,
+				CELHealthCheckCustomization:     &map[string]string{"apps_v1_Deployment": `false`},
+				CELHealthCheckResourceOverrides: map[string]string{"worker": `true`},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("deployment web: health check expression evaluated to false"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "deployment web: health check expression evaluated to false"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_FALSE,
+						"worker": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"Invalid": {
+			reason: "An invalid resource override should be reported and ignored",
+			input: &v1beta1.Input{CELHealthCheckResourceOverrides: map[string]string{
+				"worker": `object.status.availableReplicas`,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult("invalid CEL health check customizations: resource worker: celQuery does not return a bool or map type"),
+					progressingResult("deployment web: 1/3 replicas available"),
+					progressingResult("deployment worker: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment web: 1/3 replicas available", "deployment worker: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_UNSPECIFIED,
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): true,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"web":    {Resource: resource.MustStructJSON(deployment)},
+						"worker": {Resource: resource.MustStructJSON(deployment)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"web":    {Resource: resource.MustStructJSON(`{}`)},
+						"worker": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCELHealthCheckRules(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	deployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"spec": {"replicas": 3},
+		"status": {"updatedReplicas": 3, "availableReplicas": 1, "conditions": [{"type": "Available", "status": "True"}]}
+	}`
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   *fnv1.RunFunctionResponse
+	}{
+		"ResourceSelector": {
+			reason: "A rule with a resource selector should only apply to the composed resources it selects",
+			input: &v1beta1.Input{CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+				Group:      "apps",
+				Kind:       "Deployment",
+				Resource:   &v1beta1.ResourceSelector{NameRegex: "work.*"},
+				Expression: `object.status.availableReplicas >= 1`,
+			}}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("deployment web: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment web: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_UNSPECIFIED,
+						"worker": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"Priority": {
+			reason: "A rule with a higher priority should take precedence over a customization keyed by GVK",
+			input: &v1beta1.Input{
+				CELHealthCheckCustomization: &map[string]string{"apps_v1_Deployment": `false`},
+				CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+					Name:       "any-apps",
+					Group:      "apps",
+					Kind:       "*",
+					Expression: `true`,
+					Priority:   1,
+				}},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_TRUE,
+						"worker": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"MessageExpression": {
+			reason: "A rule's message expression should replace the message of its result",
+			input: &v1beta1.Input{CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+				Group:             "apps",
+				Kind:              "Deployment",
+				Resource:          &v1beta1.ResourceSelector{NameRegex: "web"},
+				Expression:        `object.status.availableReplicas == object.spec.replicas`,
+				MessageExpression: `string(object.status.availableReplicas) + '/' + string(object.spec.replicas) + ' replicas available'`,
+			}}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("deployment web: 1/3 replicas available"),
+					progressingResult("deployment worker: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "deployment web: 1/3 replicas available", "deployment worker: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_FALSE,
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"UnhealthyPolicyUnspecified": {
+			reason: "A resource a rule reports degraded should keep an unspecified readiness when the unhealthy policy is explicitly Unspecified",
+			input: &v1beta1.Input{
+				UnhealthyPolicy: v1beta1.UnhealthyPolicyUnspecified,
+				CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+					Group:      "apps",
+					Kind:       "Deployment",
+					Resource:   &v1beta1.ResourceSelector{NameRegex: "web"},
+					Expression: `object.status.availableReplicas == object.spec.replicas`,
+				}},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("deployment web: health check expression evaluated to false"),
+					progressingResult("deployment worker: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "deployment web: health check expression evaluated to false", "deployment worker: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_UNSPECIFIED,
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"FailurePolicyFail": {
+			reason: "A rule that fails to evaluate should report the composed resource degraded if its failure policy is Fail",
+			input: &v1beta1.Input{
+				UnhealthyPolicy: v1beta1.UnhealthyPolicyFalse,
+				CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+					Name:          "missing-field",
+					Group:         "apps",
+					Kind:          "Deployment",
+					Resource:      &v1beta1.ResourceSelector{NameRegex: "web"},
+					Expression:    `object.status.readyReplicas == 3`,
+					FailurePolicy: v1beta1.CELFailurePolicyFail,
+				}},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("deployment web: health check rule missing-field failed: failed to eval the program: no such key: readyReplicas"),
+					progressingResult("deployment worker: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "deployment web: health check rule missing-field failed: failed to eval the program: no such key: readyReplicas", "deployment worker: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_FALSE,
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"Invalid": {
+			reason: "Rules without a kind should be reported and ignored",
+			input: &v1beta1.Input{CELHealthCheckRules: []v1beta1.CELHealthCheckRule{{
+				Group:      "apps",
+				Expression: `true`,
+			}}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult("invalid CEL health check customizations: celHealthCheckRules[0]: kind is required"),
+					progressingResult("deployment web: 1/3 replicas available"),
+					progressingResult("deployment worker: 1/3 replicas available"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment web: 1/3 replicas available", "deployment worker: 1/3 replicas available"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"web":    fnv1.Ready_READY_UNSPECIFIED,
+						"worker": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): true,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"web":    {Resource: resource.MustStructJSON(deployment)},
+						"worker": {Resource: resource.MustStructJSON(deployment)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"web":    {Resource: resource.MustStructJSON(`{}`)},
+						"worker": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLuaHealthcheckCustomizations(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	certificate := `{
+		"apiVersion": "cert-manager.io/v1",
+		"kind": "Certificate",
+		"status": {"conditions": [{"type": "Issuing", "status": "True", "message": "Issuing certificate"}]}
+	}`
+	issuing := `
+hs = {}
+for i, condition in ipairs(obj.status.conditions) do
+  if condition.type == "Issuing" and condition.status == "True" then
+    hs.status = "Healthy"
+    hs.message = condition.message
+    return hs
+  end
+end
+hs.status = "Progressing"
+return hs
+`
+
+	cases := map[string]struct {
+		reason           string
+		enabled          map[string]bool
+		instructionLimit uint64
+		input            *v1beta1.Input
+		ctx              string
+		want             *fnv1.RunFunctionResponse
+	}{
+		"Inline": {
+			reason:  "An inline Lua health check should determine the health of composed resources of its kind",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input: &v1beta1.Input{LuaHealthCheckCustomization: map[string]string{
+				"cert-manager.io_Certificate": issuing,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"FromContext": {
+			reason:  "Lua health checks in Argo CD's argocd-cm key format should be read from context",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input:   &v1beta1.Input{LuaHealthCheckCustomizationFrom: ptr.To("argocd")},
+			ctx:     `{"argocd": {"resource.customizations.health.cert-manager.io_Certificate": "return {status = 'Healthy'}"}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Context: resource.MustStructJSON(`{"argocd": {"resource.customizations.health.cert-manager.io_Certificate": "return {status = 'Healthy'}"}}`),
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"InlineOverridesContext": {
+			reason:  "Inline Lua health checks should take precedence over Lua health checks from context",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input: &v1beta1.Input{
+				LuaHealthCheckCustomizationFrom: ptr.To("argocd"),
+				LuaHealthCheckCustomization: map[string]string{
+					"cert-manager.io_Certificate": issuing,
+				},
+			},
+			ctx: `{"argocd": {"resource.customizations.health.cert-manager.io_Certificate": "return {status = 'Degraded'}"}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Context: resource.MustStructJSON(`{"argocd": {"resource.customizations.health.cert-manager.io_Certificate": "return {status = 'Degraded'}"}}`),
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"CELTakesPrecedence": {
+			reason: "A CEL health check should take precedence over a Lua health check",
+			enabled: map[string]bool{
+				string(features.CELHealthcheckCustomizations): true,
+				string(features.LuaHealthcheckCustomizations): true,
+			},
+			input: &v1beta1.Input{
+				CELHealthCheckCustomization: &map[string]string{"cert-manager.io_v1_Certificate": `false`},
+				LuaHealthCheckCustomization: map[string]string{"cert-manager.io_Certificate": issuing},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("certificate certificate: health check expression evaluated to false"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "certificate certificate: health check expression evaluated to false"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_FALSE,
+					}),
+				},
+			},
+		},
+		"Disabled": {
+			reason:  "Lua health checks should be ignored if the alpha feature is disabled",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): false},
+			input: &v1beta1.Input{LuaHealthCheckCustomization: map[string]string{
+				"cert-manager.io_Certificate": issuing,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("certificate certificate: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "certificate certificate: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"Invalid": {
+			reason:  "Invalid Lua health checks should be reported and ignored",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input: &v1beta1.Input{LuaHealthCheckCustomization: map[string]string{
+				"cert-manager.io_v1_Certificate": issuing,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult("invalid Lua health check customizations: cert-manager.io_v1_Certificate: key must be of the form <group>_<kind>, optionally prefixed with resource.customizations.health."),
+					progressingResult("certificate certificate: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "certificate certificate: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"RuntimeError": {
+			reason:  "A Lua health check that fails should be reported and fall back to the next health check",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input: &v1beta1.Input{LuaHealthCheckCustomization: map[string]string{
+				"cert-manager.io_Certificate": `return "Healthy"`,
+			}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult(`cannot evaluate Lua health check for composed resource "certificate": health check script returned string, not a table`),
+					progressingResult("certificate certificate: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "certificate certificate: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"InstructionLimit": {
+			reason:  "A Lua health check that exceeds the instruction limit requested by the input should be reported and fall back to the next health check",
+			enabled: map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			input: &v1beta1.Input{
+				LuaInstructionLimit:         ptr.To[int64](100),
+				LuaHealthCheckCustomization: map[string]string{"cert-manager.io_Certificate": `while true do end`},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult(`cannot evaluate Lua health check for composed resource "certificate": health check script exceeded its instruction limit of 100`),
+					progressingResult("certificate certificate: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "certificate certificate: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"InstructionLimitCeiling": {
+			reason:           "The instruction limit requested by the input should not exceed the Function's ceiling",
+			enabled:          map[string]bool{string(features.LuaHealthcheckCustomizations): true},
+			instructionLimit: 100,
+			input: &v1beta1.Input{
+				LuaInstructionLimit:         ptr.To[int64](1000000),
+				LuaHealthCheckCustomization: map[string]string{"cert-manager.io_Certificate": `while true do end`},
+			},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					warningResult(`cannot evaluate Lua health check for composed resource "certificate": health check script exceeded its instruction limit of 100`),
+					progressingResult("certificate certificate: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "certificate certificate: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"certificate": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): false,
+				string(features.LuaHealthcheckCustomizations): false,
+			})
+			_ = features.FeatureGate.SetFromMap(tc.enabled)
+			t.Cleanup(func() {
+				_ = features.FeatureGate.SetFromMap(map[string]bool{string(features.LuaHealthcheckCustomizations): false})
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL, luaInstructionLimit: tc.instructionLimit}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"certificate": {Resource: resource.MustStructJSON(certificate)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"certificate": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			if tc.ctx != "" {
+				req.Context = resource.MustStructJSON(tc.ctx)
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthCheckCatalog(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	rollout := `{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind": "Rollout",
+		"status": {"phase": "Healthy"}
+	}`
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   *fnv1.RunFunctionResponse
+	}{
+		"Enabled": {
+			reason: "A health check from the catalog should be used if its group is enabled",
+			input:  &v1beta1.Input{HealthCheckCatalog: []string{"argoproj.io"}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"rollout": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"NotEnabled": {
+			reason: "Health checks from the catalog should not be used unless their group is enabled",
+			input:  &v1beta1.Input{},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("rollout rollout: waiting for Ready condition"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "rollout rollout: waiting for Ready condition"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"rollout": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"UnknownGroup": {
+			reason: "Enabling a group without health checks in the catalog should return a fatal result",
+			input:  &v1beta1.Input{HealthCheckCatalog: []string{"example.org"}},
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult(`cannot enable health check catalog: no health checks for API group "example.org", must be one of argoproj.io, external-secrets.io, helm.toolkit.fluxcd.io, keda.sh, kustomize.toolkit.fluxcd.io, networking.istio.io, postgresql.cnpg.io, serving.knative.dev`),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"rollout": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"rollout": {Resource: resource.MustStructJSON(rollout)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"rollout": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequireSynced(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+
+	cases := map[string]struct {
+		reason   string
+		input    *v1beta1.Input
+		observed string
+		want     *fnv1.RunFunctionResponse
+	}{
+		"Synced": {
+			reason:   "A managed resource that is Ready and Synced should be ready",
+			input:    &v1beta1.Input{RequireSynced: true},
+			observed: `{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","status":{"conditions":[{"type":"Ready","status":"True"},{"type":"Synced","status":"True"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"ReconcileError": {
+			reason:   "A managed resource that is Ready but failed to sync should be degraded",
+			input:    &v1beta1.Input{RequireSynced: true, UnhealthyPolicy: v1beta1.UnhealthyPolicyFalse},
+			observed: `{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","status":{"conditions":[{"type":"Ready","status":"True"},{"type":"Synced","status":"False","reason":"ReconcileError","message":"cannot update bucket"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					degradedResult("bucket resource: Ready, but Synced condition is False: ReconcileError: cannot update bucket"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonDegraded, "bucket resource: Ready, but Synced condition is False: ReconcileError: cannot update bucket"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_FALSE,
+					}),
+				},
+			},
+		},
+		"SyncedNotRequired": {
+			reason:   "A managed resource that is Ready but failed to sync should be ready unless Synced is required",
+			input:    &v1beta1.Input{},
+			observed: `{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","status":{"conditions":[{"type":"Ready","status":"True"},{"type":"Synced","status":"False","reason":"ReconcileError","message":"cannot update bucket"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"NoSyncedCondition": {
+			reason:   "A resource without a Synced condition should be ready if it's Ready",
+			input:    &v1beta1.Input{RequireSynced: true},
+			observed: `{"apiVersion":"cert-manager.io/v1","kind":"Certificate","status":{"conditions":[{"type":"Ready","status":"True"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"NestedXRNotResponsive": {
+			reason:   "A nested composite resource that is Ready and Synced but not Responsive should not be ready",
+			input:    &v1beta1.Input{RequireSynced: true},
+			observed: `{"apiVersion":"example.org/v1","kind":"XNetwork","spec":{"crossplane":{"compositionRef":{"name":"network"}}},"status":{"conditions":[{"type":"Ready","status":"True"},{"type":"Synced","status":"True"},{"type":"Responsive","status":"False","reason":"WatchCircuitOpen"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("xnetwork resource: Ready, but Responsive condition is False: WatchCircuitOpen"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "xnetwork resource: Ready, but Responsive condition is False: WatchCircuitOpen"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"LegacyNestedXRResponsive": {
+			reason:   "A legacy nested composite resource that is Ready, Synced and Responsive should be ready",
+			input:    &v1beta1.Input{RequireSynced: true},
+			observed: `{"apiVersion":"example.org/v1","kind":"XNetwork","spec":{"compositionRef":{"name":"network"}},"status":{"conditions":[{"type":"Ready","status":"True"},{"type":"Synced","status":"True"},{"type":"Responsive","status":"True"}]}}`,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"resource": {Resource: resource.MustStructJSON(tc.observed)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"resource": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestStaleStatus(t *testing.T) {
+	xr := `{"apiVersion":"example.org/v1","kind":"XR","metadata":{"name":"cool-xr"}}`
+	staleDeployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"generation": 2},
+		"spec": {"replicas": 1},
+		"status": {"observedGeneration": 1, "updatedReplicas": 1, "availableReplicas": 1, "conditions": [{"type": "Available", "status": "True"}]}
+	}`
+	currentDeployment := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"generation": 2},
+		"spec": {"replicas": 1},
+		"status": {"observedGeneration": 2, "updatedReplicas": 1, "availableReplicas": 1, "conditions": [{"type": "Available", "status": "True"}]}
+	}`
+	staleBucket := `{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind": "Bucket",
+		"metadata": {"generation": 3},
+		"status": {"conditions": [{"type": "Ready", "status": "True", "observedGeneration": 2}]}
+	}`
+	staleSyncedBucket := `{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind": "Bucket",
+		"metadata": {"generation": 3},
+		"status": {"conditions": [
+			{"type": "Ready", "status": "True", "observedGeneration": 3},
+			{"type": "Synced", "status": "True", "observedGeneration": 2}
+		]}
+	}`
+
+	cases := map[string]struct {
+		reason   string
+		enabled  bool
+		input    *v1beta1.Input
+		observed string
+		want     *fnv1.RunFunctionResponse
+	}{
+		"Current": {
+			reason:   "A composed resource with a current status should be evaluated by its health check",
+			input:    &v1beta1.Input{},
+			observed: currentDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"StaleObservedGeneration": {
+			reason:   "A composed resource whose observedGeneration is behind its generation should be progressing",
+			input:    &v1beta1.Input{},
+			observed: staleDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("deployment resource: status is stale: observed generation 1 is behind generation 2"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment resource: status is stale: observed generation 1 is behind generation 2"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"StaleReadyCondition": {
+			reason:   "A composed resource whose Ready condition was observed at an older generation should be progressing",
+			input:    &v1beta1.Input{},
+			observed: staleBucket,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("bucket resource: status is stale: Ready condition observed generation 2 is behind generation 3"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "bucket resource: status is stale: Ready condition observed generation 2 is behind generation 3"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"StaleSyncedCondition": {
+			reason:   "A composed resource whose Synced condition was observed at an older generation should be progressing, even if its Ready condition is current",
+			input:    &v1beta1.Input{},
+			observed: staleSyncedBucket,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("bucket resource: status is stale: Synced condition observed generation 2 is behind generation 3"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "bucket resource: status is stale: Synced condition observed generation 2 is behind generation 3"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"StaleCEL": {
+			reason:   "A composed resource with a stale status should be progressing even if a CEL health check applies to it",
+			enabled:  true,
+			input:    &v1beta1.Input{CELHealthCheckCustomization: &map[string]string{"apps_v1_Deployment": `true`}},
+			observed: staleDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					progressingResult("deployment resource: status is stale: observed generation 1 is behind generation 2"),
+				},
+				Conditions: []*fnv1.Condition{
+					unhealthyCondition(ReasonProgressing, "deployment resource: status is stale: observed generation 1 is behind generation 2"),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+		"Ignore": {
+			reason:   "A stale status should be ignored if the policy says so",
+			input:    &v1beta1.Input{StaleStatusPolicy: v1beta1.StaleStatusPolicyIgnore},
+			observed: staleDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"IgnoreOverride": {
+			reason: "A stale status should be ignored if the policy for the composed resource's kind says so",
+			input: &v1beta1.Input{StaleStatusPolicyOverrides: map[string]v1beta1.StaleStatusPolicy{
+				"apps_*_Deployment": v1beta1.StaleStatusPolicyIgnore,
+			}},
+			observed: staleDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Conditions: []*fnv1.Condition{
+					healthyCondition(),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_TRUE,
+					}),
+				},
+			},
+		},
+		"InvalidOverride": {
+			reason: "An override with an invalid key should return a fatal result",
+			input: &v1beta1.Input{StaleStatusPolicyOverrides: map[string]v1beta1.StaleStatusPolicy{
+				"Deployment": v1beta1.StaleStatusPolicyIgnore,
+			}},
+			observed: staleDeployment,
+			want: &fnv1.RunFunctionResponse{
+				Meta: &fnv1.ResponseMeta{Ttl: durationpb.New(response.DefaultTTL)},
+				Results: []*fnv1.Result{
+					fatalResult(`cannot parse stale status policy: invalid key "Deployment": must be of the form <group>_<version>_<kind>, where the version and kind may be *`),
+				},
+				Desired: &fnv1.State{
+					Resources: desiredResources(map[string]fnv1.Ready{
+						"resource": fnv1.Ready_READY_UNSPECIFIED,
+					}),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_ = features.FeatureGate.SetFromMap(map[string]bool{
+				string(features.CELHealthcheckCustomizations): tc.enabled,
+			})
+
+			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
+			req := &fnv1.RunFunctionRequest{
+				Input: resource.MustStructObject(tc.input),
+				Observed: &fnv1.State{
+					Composite: &fnv1.Resource{
+						Resource: resource.MustStructJSON(xr),
+					},
+					Resources: map[string]*fnv1.Resource{
+						"resource": {Resource: resource.MustStructJSON(tc.observed)},
+					},
+				},
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						"resource": {Resource: resource.MustStructJSON(`{}`)},
+					},
+				},
+			}
+
+			rsp, err := f.RunFunction(context.Background(), req)
+			if diff := cmp.Diff(tc.want, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// The builders below return the parts of a RunFunctionResponse that most
+// tests expect, targeting the composite resource.
+
+func progressingResult(msg string) *fnv1.Result {
+	return &fnv1.Result{
+		Severity: fnv1.Severity_SEVERITY_NORMAL,
+		Message:  msg,
+		Reason:   ptr.To("Progressing"),
+		Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+func degradedResult(msg string) *fnv1.Result {
+	return &fnv1.Result{
+		Severity: fnv1.Severity_SEVERITY_WARNING,
+		Message:  msg,
+		Reason:   ptr.To("Degraded"),
+		Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+func warningResult(msg string) *fnv1.Result {
+	return &fnv1.Result{
+		Severity: fnv1.Severity_SEVERITY_WARNING,
+		Message:  msg,
+		Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+func fatalResult(msg string) *fnv1.Result {
+	return &fnv1.Result{
+		Severity: fnv1.Severity_SEVERITY_FATAL,
+		Message:  msg,
+		Target:   fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+func healthyCondition() *fnv1.Condition {
+	return &fnv1.Condition{
+		Type:   ConditionTypeResourcesHealthy,
+		Status: fnv1.Status_STATUS_CONDITION_TRUE,
+		Reason: ReasonAllHealthy,
+		Target: fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+func unhealthyCondition(reason string, msgs ...string) *fnv1.Condition {
+	return &fnv1.Condition{
+		Type:    ConditionTypeResourcesHealthy,
+		Status:  fnv1.Status_STATUS_CONDITION_FALSE,
+		Reason:  reason,
+		Message: ptr.To("Composed resources not healthy: " + strings.Join(msgs, "; ")),
+		Target:  fnv1.Target_TARGET_COMPOSITE.Enum(),
+	}
+}
+
+// desiredResources returns empty desired composed resources with the
+// supplied readiness.
+func desiredResources(ready map[string]fnv1.Ready) map[string]*fnv1.Resource {
+	rs := make(map[string]*fnv1.Resource, len(ready))
+	for name, r := range ready {
+		rs[name] = &fnv1.Resource{Resource: resource.MustStructJSON(`{}`), Ready: r}
 	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			_ = features.FeatureGate.SetFromMap(map[string]bool{
-				string(features.CELHealthcheckCustomizations): true,
-			})
-
-			f := &Function{log: logging.NewNopLogger(), ttl: response.DefaultTTL}
-			rsp, err := f.RunFunction(tc.args.ctx, tc.args.req)
-
-			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
-			}
-
-			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
-				t.Errorf("%s\nf.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
-			}
-		})
-	}
+	return rs
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/response"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// Reasons of the results emitted for readiness groups.
+const (
+	ReasonQuorumReached    = "QuorumReached"
+	ReasonQuorumNotReached = "QuorumNotReached"
+)
+
+// applyReadinessGroups marks every member of a readiness group ready once at
+// least MinReady of its members are healthy. Members are the evaluated
+// composed resources matched by the group's selectors. It emits a result for
+// every group listing the healthy members it counted.
+func applyReadinessGroups(rsp *fnv1.RunFunctionResponse, in *v1beta1.Input, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed, health map[resource.Name]healthchecks.HealthStatus) error {
+	for _, g := range in.ReadinessGroups {
+		if len(g.Resources) == 0 {
+			return errors.Errorf("readiness group %q must select at least one resource", g.Name)
+		}
+		if g.MinReady < 1 {
+			return errors.Errorf("readiness group %q minReady must be at least 1", g.Name)
+		}
+		selectors, err := compileSelectors(g.Resources)
+		if err != nil {
+			return errors.Wrapf(err, "cannot compile selectors of readiness group %q", g.Name)
+		}
+
+		members := make([]resource.Name, 0)
+		healthy := make([]string, 0)
+		for _, name := range slices.Sorted(maps.Keys(health)) {
+			if !matchesAny(selectors, name, desired[name], observed[name]) {
+				continue
+			}
+			members = append(members, name)
+			if health[name].IsHealthy() {
+				healthy = append(healthy, string(name))
+			}
+		}
+
+		counted := "none"
+		if len(healthy) > 0 {
+			counted = strings.Join(healthy, ", ")
+		}
+		msg := fmt.Sprintf("readiness group %s: %d/%d members healthy (%s)", g.Name, len(healthy), len(members), counted)
+
+		var r *response.ResultOption
+		if len(healthy) >= g.MinReady {
+			for _, name := range members {
+				desired[name].Ready = resource.ReadyTrue
+			}
+			r = response.Normalf(rsp, "%s, marking all members ready", msg).WithReason(ReasonQuorumReached)
+		} else {
+			r = response.Normalf(rsp, "%s, need %d", msg, g.MinReady).WithReason(ReasonQuorumNotReached)
+		}
+		if in.ResultTarget == v1beta1.TargetCompositeAndClaim {
+			r.TargetCompositeAndClaim()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Package gvkkey matches kinds of Kubernetes resource against keys of the
+// form <group>_<version>_<kind>. The version and kind of a key may be the
+// wildcard *, so that one key can cover every version or kind in a group.
+package gvkkey
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Wildcard matches any version or kind.
+const Wildcard = "*"
+
+// pattern matches well-formed keys. The group is empty for the core API group.
+var pattern = regexp.MustCompile(`^([a-z0-9]([-a-z0-9.]*[a-z0-9])?)?_(v[0-9]+((alpha|beta)[0-9]+)?|\*)_([A-Z][A-Za-z0-9]*|\*)$`)
+
+// Valid returns true if the supplied key is of the form
+// <group>_<version>_<kind>, where the version and kind may be wildcards.
+func Valid(key string) bool {
+	return pattern.MatchString(key)
+}
+
+// Exact returns the exact key of the supplied GVK.
+func Exact(gvk schema.GroupVersionKind) string {
+	return gvk.Group + "_" + gvk.Version + "_" + gvk.Kind
+}
+
+// Candidates returns the keys that match the supplied GVK, from most to least
+// specific: the exact key, any version of the kind, any kind of the version,
+// then any kind in the group.
+func Candidates(gvk schema.GroupVersionKind) []string {
+	return []string{
+		Exact(gvk),
+		gvk.Group + "_" + Wildcard + "_" + gvk.Kind,
+		gvk.Group + "_" + gvk.Version + "_" + Wildcard,
+		gvk.Group + "_" + Wildcard + "_" + Wildcard,
+	}
+}
+
+// Lookup returns the value of the most specific key in the supplied map that
+// matches the supplied GVK.
+func Lookup[V any](m map[string]V, gvk schema.GroupVersionKind) (V, bool) {
+	for _, k := range Candidates(gvk) {
+		if v, ok := m[k]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
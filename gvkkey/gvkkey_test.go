@@ -0,0 +1,88 @@
+package gvkkey
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestValid(t *testing.T) {
+	cases := map[string]struct {
+		key  string
+		want bool
+	}{
+		"Exact":            {key: "s3.aws.upbound.io_v1beta1_Bucket", want: true},
+		"CoreGroup":        {key: "_v1_ConfigMap", want: true},
+		"AnyVersion":       {key: "s3.aws.upbound.io_*_Bucket", want: true},
+		"AnyKind":          {key: "s3.aws.upbound.io_v1beta2_*", want: true},
+		"AnyVersionOrKind": {key: "s3.aws.upbound.io_*_*", want: true},
+		"AnyGroup":         {key: "*_v1_Bucket", want: false},
+		"Slashes":          {key: "s3.aws.upbound.io/v1beta1/Bucket", want: false},
+		"MissingVersion":   {key: "s3.aws.upbound.io_Bucket", want: false},
+		"LowercaseKind":    {key: "s3.aws.upbound.io_v1_bucket", want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Valid(tc.key); got != tc.want {
+				t.Errorf("Valid(%q): want %t, got %t", tc.key, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	m := map[string]string{
+		"s3.aws.upbound.io_v1beta1_Bucket": "exact",
+		"s3.aws.upbound.io_*_Bucket":       "any-version",
+		"s3.aws.upbound.io_v1beta2_*":      "any-kind",
+		"s3.aws.upbound.io_*_*":            "group",
+	}
+
+	type want struct {
+		value string
+		found bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		gvk    schema.GroupVersionKind
+		want   want
+	}{
+		"Exact": {
+			reason: "An exact key should take precedence over wildcard keys",
+			gvk:    schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta1", Kind: "Bucket"},
+			want:   want{value: "exact", found: true},
+		},
+		"AnyVersion": {
+			reason: "A key matching any version of the kind should take precedence over one matching any kind",
+			gvk:    schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta2", Kind: "Bucket"},
+			want:   want{value: "any-version", found: true},
+		},
+		"AnyKind": {
+			reason: "A key matching any kind of the version should take precedence over one matching the group",
+			gvk:    schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1beta2", Kind: "BucketPolicy"},
+			want:   want{value: "any-kind", found: true},
+		},
+		"Group": {
+			reason: "A key matching any version and kind should match any kind in the group",
+			gvk:    schema.GroupVersionKind{Group: "s3.aws.upbound.io", Version: "v1", Kind: "BucketACL"},
+			want:   want{value: "group", found: true},
+		},
+		"OtherGroup": {
+			reason: "Keys shouldn't match kinds in other groups",
+			gvk:    schema.GroupVersionKind{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "Bucket"},
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, found := Lookup(m, tc.gvk)
+			if diff := cmp.Diff(tc.want, want{value: v, found: found}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("%s\nLookup(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
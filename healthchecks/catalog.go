@@ -0,0 +1,147 @@
+package healthchecks
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/nested"
+)
+
+// catalog holds opt-in health checks for popular CRDs. They're keyed by group
+// and kind so they apply to every version of a CRD.
+var catalog = make(map[schema.GroupKind]HealthCheckFunc)
+
+// registerCatalogHealthCheck registers an opt-in health check function for
+// every version of a specific GroupKind.
+func registerCatalogHealthCheck(gk schema.GroupKind, fn HealthCheckFunc) {
+	catalog[gk] = fn
+}
+
+// CatalogGroups returns the API groups with health checks in the catalog.
+func CatalogGroups() []string {
+	groups := make(map[string]bool)
+	for gk := range catalog {
+		groups[gk.Group] = true
+	}
+	return slices.Sorted(maps.Keys(groups))
+}
+
+// A Catalog is the subset of the catalog of health checks for popular CRDs
+// that was opted in to.
+type Catalog map[schema.GroupKind]HealthCheckFunc
+
+// NewCatalog returns the health checks in the catalog for the supplied API
+// groups. It returns an error if the catalog has no health checks for a
+// group.
+func NewCatalog(groups []string) (Catalog, error) {
+	c := make(Catalog)
+	for _, g := range groups {
+		found := false
+		for gk, fn := range catalog {
+			if gk.Group == g {
+				c[gk] = fn
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no health checks for API group %q, must be one of %s", g, strings.Join(CatalogGroups(), ", "))
+		}
+	}
+	return c, nil
+}
+
+// GetHealthCheck retrieves the health check function for a specific
+// GroupVersionKind. Returns nil if the catalog has no health check for the
+// GVK's group and kind.
+func (c Catalog) GetHealthCheck(gvk schema.GroupVersionKind) HealthCheckFunc {
+	return c[gvk.GroupKind()]
+}
+
+// condition is a status condition of an unstructured object.
+type condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// getCondition returns the status condition of the supplied type.
+func getCondition(obj *unstructured.Unstructured, condType string) (condition, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(condMap, "type"); t != condType {
+			continue
+		}
+		c := condition{Type: condType}
+		c.Status, _, _ = unstructured.NestedString(condMap, "status")
+		c.Reason, _, _ = unstructured.NestedString(condMap, "reason")
+		c.Message, _, _ = unstructured.NestedString(condMap, "message")
+		return c, true
+	}
+	return condition{}, false
+}
+
+// generationObserved returns false if the object's status.observedGeneration
+// is behind its metadata.generation, i.e. its status doesn't reflect its spec
+// yet.
+func generationObserved(obj *unstructured.Unstructured) bool {
+	observed, found := nested.Int64(obj.Object, "status", "observedGeneration")
+	if !found {
+		return true
+	}
+	generation, _ := nested.Int64(obj.Object, "metadata", "generation")
+	return observed >= generation
+}
+
+// readyConditionHealth returns the health of an object that reports it with a
+// Ready condition. An object with a False Ready condition is degraded, and one
+// without a Ready condition or with an Unknown Ready condition is progressing.
+func readyConditionHealth(obj *unstructured.Unstructured, kind string) HealthStatus {
+	if !generationObserved(obj) {
+		return Progressing(fmt.Sprintf("waiting for %s spec update to be observed", kind))
+	}
+	c, found := getCondition(obj, "Ready")
+	switch {
+	case !found:
+		return Progressing(fmt.Sprintf("waiting for %s Ready condition", kind))
+	case c.Status == "True":
+		return Healthy(c.Message)
+	case c.Status == "False":
+		return Degraded(conditionMessage(c))
+	default:
+		return Progressing(conditionMessage(c))
+	}
+}
+
+// conditionMessage formats a condition for a health status message, e.g.
+// "Ready condition is False: ReconciliationFailed: install retries exhausted".
+func conditionMessage(c condition) string {
+	msg := fmt.Sprintf("%s condition is %s", c.Type, c.Status)
+	if c.Reason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, c.Reason)
+	}
+	if c.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, c.Message)
+	}
+	return msg
+}
+
+func init() {
+	// Register the opt-in health checks for popular CRDs
+	registerCloudNativePGHealthChecks()
+	registerExternalSecretsHealthChecks()
+	registerFluxHealthChecks()
+	registerIstioHealthChecks()
+	registerKEDAHealthChecks()
+	registerKnativeHealthChecks()
+	registerRolloutHealthChecks()
+}
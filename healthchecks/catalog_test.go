@@ -0,0 +1,112 @@
+package healthchecks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// fixtureCase is a test case for a health check that reads the object from a
+// YAML fixture in testdata/<group>/<kind>.
+type fixtureCase struct {
+	name     string
+	fixture  string
+	expected HealthStatus
+}
+
+// loadFixture reads an object from a YAML fixture in testdata.
+func loadFixture(t *testing.T, path string) *unstructured.Unstructured {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatalf("cannot read fixture: %v", err)
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		t.Fatalf("cannot convert fixture to JSON: %v", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		t.Fatalf("cannot unmarshal fixture: %v", err)
+	}
+	return obj
+}
+
+// asRequest returns the supplied object as it's decoded from a
+// RunFunctionRequest, where every number is a float64.
+func asRequest(t *testing.T, obj *unstructured.Unstructured) *unstructured.Unstructured {
+	t.Helper()
+	s, err := structpb.NewStruct(obj.Object)
+	if err != nil {
+		t.Fatalf("cannot convert fixture to a struct: %v", err)
+	}
+	return &unstructured.Unstructured{Object: s.AsMap()}
+}
+
+// testFixtures runs the supplied health check against each case's fixture,
+// both as decoded from YAML and as decoded from a RunFunctionRequest.
+func testFixtures(t *testing.T, fn HealthCheckFunc, tests []fixtureCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := loadFixture(t, tt.fixture)
+			if diff := cmp.Diff(tt.expected, fn(obj)); diff != "" {
+				t.Errorf("health check for %s: -want, +got:\n%s", tt.fixture, diff)
+			}
+			if diff := cmp.Diff(tt.expected, fn(asRequest(t, obj))); diff != "" {
+				t.Errorf("health check for %s with float64 numbers: -want, +got:\n%s", tt.fixture, diff)
+			}
+		})
+	}
+}
+
+func TestNewCatalog(t *testing.T) {
+	tests := []struct {
+		name     string
+		groups   []string
+		gvk      schema.GroupVersionKind
+		expected bool
+		err      bool
+	}{
+		{
+			name:     "enabled group - any version",
+			groups:   []string{"argoproj.io"},
+			gvk:      schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha2", Kind: "Rollout"},
+			expected: true,
+		},
+		{
+			name:     "group not enabled",
+			groups:   []string{"argoproj.io"},
+			gvk:      schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"},
+			expected: false,
+		},
+		{
+			name:     "no groups enabled",
+			gvk:      schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"},
+			expected: false,
+		},
+		{
+			name:   "unknown group",
+			groups: []string{"example.org"},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCatalog(tt.groups)
+			if (err != nil) != tt.err {
+				t.Fatalf("NewCatalog(%v): want error %t, got %v", tt.groups, tt.err, err)
+			}
+			if got := c.GetHealthCheck(tt.gvk) != nil; got != tt.expected {
+				t.Errorf("GetHealthCheck(%s) found = %t, expected %t", tt.gvk, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package healthchecks
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/nested"
+)
+
+func registerCloudNativePGHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "postgresql.cnpg.io", Kind: "Cluster"}, checkCloudNativePGClusterHealth)
+}
+
+// cnpgHealthyPhase is the phase of a healthy CloudNativePG Cluster.
+const cnpgHealthyPhase = "Cluster in healthy state"
+
+// cnpgDegradedPhases are the phases of a CloudNativePG Cluster that won't
+// recover without intervention.
+var cnpgDegradedPhases = map[string]bool{
+	"Cluster is in an unrecoverable state, needs manual intervention":                   true,
+	"Unable to create required cluster objects":                                         true,
+	"Cluster cannot proceed to reconciliation due to an unknown plugin being required":  true,
+	"Cluster cannot execute instance online upgrade due to missing architecture binary": true,
+}
+
+// checkCloudNativePGClusterHealth implements health check for CloudNativePG
+// Clusters. A Cluster is considered healthy when its phase is healthy and all
+// of its instances are ready. A hibernated Cluster is suspended, and one in a
+// phase it can't recover from is degraded.
+func checkCloudNativePGClusterHealth(obj *unstructured.Unstructured) HealthStatus {
+	if obj.GetAnnotations()["cnpg.io/hibernation"] == "on" {
+		return Suspended("cluster is hibernated")
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	reason, _, _ := unstructured.NestedString(obj.Object, "status", "phaseReason")
+	message := phase
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", phase, reason)
+	}
+
+	switch {
+	case phase == "":
+		return Progressing("waiting for cluster status")
+	case cnpgDegradedPhases[phase]:
+		return Degraded(message)
+	case phase != cnpgHealthyPhase:
+		return Progressing(message)
+	}
+
+	instances, _ := nested.Int64(obj.Object, "spec", "instances")
+	ready, _ := nested.Int64(obj.Object, "status", "readyInstances")
+	if ready < instances {
+		return Progressing(fmt.Sprintf("%d/%d instances ready", ready, instances))
+	}
+	return Healthy(fmt.Sprintf("%d/%d instances ready", ready, instances))
+}
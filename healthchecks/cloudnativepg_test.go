@@ -0,0 +1,12 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckCloudNativePGClusterHealth(t *testing.T) {
+	testFixtures(t, checkCloudNativePGClusterHealth, []fixtureCase{
+		{name: "healthy cluster", fixture: "postgresql.cnpg.io/Cluster/healthy.yaml", expected: Healthy("3/3 instances ready")},
+		{name: "cluster creating a replica", fixture: "postgresql.cnpg.io/Cluster/creating-replica.yaml", expected: Progressing("Creating a new replica: Creating replica db-3-join")},
+		{name: "unrecoverable cluster", fixture: "postgresql.cnpg.io/Cluster/unrecoverable.yaml", expected: Degraded("Cluster is in an unrecoverable state, needs manual intervention: no primary instance")},
+		{name: "hibernated cluster", fixture: "postgresql.cnpg.io/Cluster/hibernated.yaml", expected: Suspended("cluster is hibernated")},
+	})
+}
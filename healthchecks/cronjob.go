@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,26 +19,26 @@ func registerCronJobHealthCheck() {
 
 // checkCronJobHealth implements health check for CronJobs
 // Based on ArgoCD's gitops-engine implementation
-func checkCronJobHealth(obj *unstructured.Unstructured) bool {
+func checkCronJobHealth(obj *unstructured.Unstructured) HealthStatus {
 	var cronJob batchv1.CronJob
 	err := convertFromUnstructured(obj, &cronJob)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to CronJob: %s", err))
 	}
 
 	// If suspended, consider it healthy (suspended is a valid state)
 	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
-		return true
+		return Healthy("cronjob is suspended")
 	}
 
 	// If there's no status yet, it's progressing
 	if cronJob.Status.LastScheduleTime == nil {
-		return false
+		return Progressing("cronjob has not been scheduled yet")
 	}
 
 	// If there are active jobs, it's healthy (job is running)
 	if len(cronJob.Status.Active) > 0 {
-		return true
+		return Healthy(fmt.Sprintf("%d active jobs", len(cronJob.Status.Active)))
 	}
 
 	// Check if last execution was successful
@@ -44,16 +46,16 @@ func checkCronJobHealth(obj *unstructured.Unstructured) bool {
 	if cronJob.Status.LastSuccessfulTime != nil && cronJob.Status.LastScheduleTime != nil {
 		if cronJob.Status.LastSuccessfulTime.After(cronJob.Status.LastScheduleTime.Time) ||
 			cronJob.Status.LastSuccessfulTime.Time.Equal(cronJob.Status.LastScheduleTime.Time) {
-			return true
+			return Healthy("last execution succeeded")
 		}
 		// Last execution failed
-		return false
+		return Degraded("last execution failed")
 	}
 
 	// Never completed successfully
 	if cronJob.Status.LastSuccessfulTime == nil {
-		return false
+		return Degraded("cronjob has never completed successfully")
 	}
 
-	return true
+	return Healthy("")
 }
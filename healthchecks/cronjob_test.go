@@ -10,7 +10,7 @@ func TestCheckCronJobHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy cronjob - suspended",
@@ -23,7 +23,7 @@ func TestCheckCronJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy cronjob - last execution succeeded",
@@ -35,12 +35,12 @@ func TestCheckCronJobHealth(t *testing.T) {
 						"suspend": false,
 					},
 					"status": map[string]interface{}{
-						"lastScheduleTime":    "2024-01-01T10:00:00Z",
+						"lastScheduleTime":   "2024-01-01T10:00:00Z",
 						"lastSuccessfulTime": "2024-01-01T10:05:00Z",
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy cronjob - job is active",
@@ -61,7 +61,7 @@ func TestCheckCronJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy cronjob - last execution failed",
@@ -73,12 +73,12 @@ func TestCheckCronJobHealth(t *testing.T) {
 						"suspend": false,
 					},
 					"status": map[string]interface{}{
-						"lastScheduleTime":    "2024-01-01T10:05:00Z",
+						"lastScheduleTime":   "2024-01-01T10:05:00Z",
 						"lastSuccessfulTime": "2024-01-01T10:00:00Z",
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy cronjob - never succeeded",
@@ -94,7 +94,7 @@ func TestCheckCronJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "progressing cronjob - not yet scheduled",
@@ -108,15 +108,15 @@ func TestCheckCronJobHealth(t *testing.T) {
 					"status": map[string]interface{}{},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkCronJobHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkCronJobHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkCronJobHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,22 @@
+package healthchecks
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerCrossplaneAPIExtensionsHealthChecks() {
+	for _, version := range []string{"v1alpha1", "v1beta1"} {
+		RegisterHealthCheck(schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: version, Kind: "EnvironmentConfig"}, alwaysReady)
+		RegisterHealthCheck(schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: version, Kind: "Usage"}, checkUsageHealth)
+	}
+	RegisterHealthCheck(schema.GroupVersionKind{Group: "protection.crossplane.io", Version: "v1beta1", Kind: "Usage"}, checkUsageHealth)
+	RegisterHealthCheck(schema.GroupVersionKind{Group: "protection.crossplane.io", Version: "v1beta1", Kind: "ClusterUsage"}, checkUsageHealth)
+}
+
+// checkUsageHealth implements health check for Crossplane Usages and
+// ClusterUsages. A Usage is considered healthy when its Ready condition is
+// True, i.e. the used resource is protected from deletion.
+func checkUsageHealth(obj *unstructured.Unstructured) HealthStatus {
+	return readyConditionHealth(obj, "usage")
+}
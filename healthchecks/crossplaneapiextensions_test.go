@@ -0,0 +1,59 @@
+package healthchecks
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCheckUsageHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		expected HealthStatusCode
+	}{
+		{
+			name:     "ready usage",
+			obj:      objWithConditions("protection.crossplane.io/v1beta1", "Usage", "Ready", "True"),
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:     "new usage",
+			obj:      objWithConditions("protection.crossplane.io/v1beta1", "Usage"),
+			expected: HealthStatusProgressing,
+		},
+		{
+			name:     "usage that can't protect its resource",
+			obj:      objWithConditions("apiextensions.crossplane.io/v1beta1", "Usage", "Ready", "False"),
+			expected: HealthStatusDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkUsageHealth(tt.obj)
+			if result.Status != tt.expected {
+				t.Errorf("checkUsageHealth() = %v (%s), expected %v", result.Status, result.Message, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCrossplaneAlwaysReadyHealthChecks(t *testing.T) {
+	for _, gvk := range []schema.GroupVersionKind{
+		{Group: "apiextensions.crossplane.io", Version: "v1beta1", Kind: "EnvironmentConfig"},
+		{Group: "apiextensions.crossplane.io", Version: "v1alpha1", Kind: "EnvironmentConfig"},
+		{Group: "pkg.crossplane.io", Version: "v1beta1", Kind: "DeploymentRuntimeConfig"},
+	} {
+		t.Run(gvk.String(), func(t *testing.T) {
+			fn := GetHealthCheck(gvk)
+			if fn == nil {
+				t.Fatalf("GetHealthCheck(%s) = nil, expected a health check", gvk)
+			}
+			if result := fn(&unstructured.Unstructured{Object: map[string]interface{}{}}); !result.IsHealthy() {
+				t.Errorf("health check for %s = %v, expected %v", gvk, result.Status, HealthStatusHealthy)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package healthchecks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerCrossplanePackageHealthChecks() {
+	packages := map[string][]string{
+		"Provider":      {"v1"},
+		"Configuration": {"v1"},
+		"Function":      {"v1", "v1beta1"},
+	}
+	for kind, versions := range packages {
+		for _, version := range versions {
+			RegisterHealthCheck(schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: version, Kind: kind}, checkCrossplanePackageHealth)
+			RegisterHealthCheck(schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: version, Kind: kind + "Revision"}, checkCrossplanePackageRevisionHealth)
+		}
+	}
+	RegisterHealthCheck(schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1beta1", Kind: "DeploymentRuntimeConfig"}, alwaysReady)
+}
+
+// checkCrossplanePackageHealth implements health check for Crossplane
+// Providers, Configurations and Functions.
+// A package is considered healthy when:
+// 1. status.conditions contains "Installed" with status "True"
+// 2. status.conditions contains "Healthy" with status "True"
+// A package whose Healthy condition is False is degraded.
+func checkCrossplanePackageHealth(obj *unstructured.Unstructured) HealthStatus {
+	kind := strings.ToLower(obj.GetKind())
+
+	installed, found := getCondition(obj, "Installed")
+	if !found {
+		return Progressing(fmt.Sprintf("waiting for %s to be installed", kind))
+	}
+	if installed.Status != "True" {
+		return Progressing(conditionMessage(installed))
+	}
+
+	healthy, found := getCondition(obj, "Healthy")
+	switch {
+	case !found:
+		return Progressing(fmt.Sprintf("waiting for %s Healthy condition", kind))
+	case healthy.Status == "False":
+		return Degraded(conditionMessage(healthy))
+	case healthy.Status != "True":
+		return Progressing(conditionMessage(healthy))
+	}
+
+	return Healthy(fmt.Sprintf("%s is installed and healthy", kind))
+}
+
+// checkCrossplanePackageRevisionHealth implements health check for the
+// revisions of Crossplane Providers, Configurations and Functions.
+// A revision is considered healthy when its RevisionHealthy condition, and
+// its RuntimeHealthy condition if it has a runtime, are True. Revisions
+// created by Crossplane versions before v2 report a Healthy condition instead.
+func checkCrossplanePackageRevisionHealth(obj *unstructured.Unstructured) HealthStatus {
+	types := []string{"RevisionHealthy", "RuntimeHealthy"}
+	if _, found := getCondition(obj, "RevisionHealthy"); !found {
+		types = []string{"Healthy"}
+	}
+
+	for _, t := range types {
+		c, found := getCondition(obj, t)
+		switch {
+		case !found && t == "RuntimeHealthy":
+			// Configuration revisions don't have a runtime.
+			continue
+		case !found:
+			return Progressing(fmt.Sprintf("waiting for %s condition", t))
+		case c.Status == "False":
+			return Degraded(conditionMessage(c))
+		case c.Status != "True":
+			return Progressing(conditionMessage(c))
+		}
+	}
+
+	return Healthy("revision is healthy")
+}
@@ -0,0 +1,124 @@
+package healthchecks
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// objWithConditions returns an object of the supplied kind with the supplied
+// status conditions, each a type and status pair.
+func objWithConditions(apiVersion, kind string, conditions ...string) *unstructured.Unstructured {
+	conds := make([]interface{}, 0, len(conditions)/2)
+	for i := 0; i+1 < len(conditions); i += 2 {
+		conds = append(conds, map[string]interface{}{
+			"type":   conditions[i],
+			"status": conditions[i+1],
+		})
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"status": map[string]interface{}{
+				"conditions": conds,
+			},
+		},
+	}
+}
+
+func TestCheckCrossplanePackageHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		expected HealthStatusCode
+	}{
+		{
+			name:     "healthy provider - installed and healthy",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Provider", "Installed", "True", "Healthy", "True"),
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:     "configuration still unpacking",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Configuration", "Installed", "False"),
+			expected: HealthStatusProgressing,
+		},
+		{
+			name:     "function with unknown health",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Function", "Installed", "True", "Healthy", "Unknown"),
+			expected: HealthStatusProgressing,
+		},
+		{
+			name:     "unhealthy provider",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Provider", "Installed", "True", "Healthy", "False"),
+			expected: HealthStatusDegraded,
+		},
+		{
+			name:     "new provider without conditions",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Provider"),
+			expected: HealthStatusProgressing,
+		},
+		{
+			name:     "installed but not healthy - ready condition is ignored",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "Configuration", "Installed", "True", "Ready", "True"),
+			expected: HealthStatusProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCrossplanePackageHealth(tt.obj)
+			if result.Status != tt.expected {
+				t.Errorf("checkCrossplanePackageHealth() = %v (%s), expected %v", result.Status, result.Message, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckCrossplanePackageRevisionHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		expected HealthStatusCode
+	}{
+		{
+			name:     "healthy provider revision - revision and runtime healthy",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "ProviderRevision", "RevisionHealthy", "True", "RuntimeHealthy", "True"),
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:     "provider revision with unhealthy runtime",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "ProviderRevision", "RevisionHealthy", "True", "RuntimeHealthy", "False"),
+			expected: HealthStatusDegraded,
+		},
+		{
+			name:     "healthy configuration revision - no runtime",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "ConfigurationRevision", "RevisionHealthy", "True"),
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:     "function revision with unknown health",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "FunctionRevision", "RevisionHealthy", "Unknown"),
+			expected: HealthStatusProgressing,
+		},
+		{
+			name:     "healthy revision created before crossplane v2",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "ProviderRevision", "Healthy", "True"),
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:     "new revision without conditions",
+			obj:      objWithConditions("pkg.crossplane.io/v1", "ProviderRevision"),
+			expected: HealthStatusProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCrossplanePackageRevisionHealth(tt.obj)
+			if result.Status != tt.expected {
+				t.Errorf("checkCrossplanePackageRevisionHealth() = %v (%s), expected %v", result.Status, result.Message, tt.expected)
+			}
+		})
+	}
+}
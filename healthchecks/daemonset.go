@@ -1,8 +1,12 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/nested"
 )
 
 func registerDaemonSetHealthCheck() {
@@ -19,33 +23,40 @@ func registerDaemonSetHealthCheck() {
 // 1. status.desiredNumberScheduled == status.numberReady
 // 2. status.updatedNumberScheduled == status.desiredNumberScheduled
 // 3. status.numberAvailable == status.desiredNumberScheduled
-func checkDaemonSetHealth(obj *unstructured.Unstructured) bool {
+func checkDaemonSetHealth(obj *unstructured.Unstructured) HealthStatus {
 	// Get status.desiredNumberScheduled
-	desiredNumberScheduled, found := getInt64Field(obj.Object, "status", "desiredNumberScheduled")
+	desiredNumberScheduled, found := nested.Int64(obj.Object, "status", "desiredNumberScheduled")
 	if !found {
-		return false
+		return Progressing("waiting for pods to be scheduled")
 	}
 
 	// Get status.numberReady
-	numberReady, found := getInt64Field(obj.Object, "status", "numberReady")
+	numberReady, found := nested.Int64(obj.Object, "status", "numberReady")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d pods ready", desiredNumberScheduled))
 	}
 
 	// Get status.updatedNumberScheduled
-	updatedNumberScheduled, found := getInt64Field(obj.Object, "status", "updatedNumberScheduled")
+	updatedNumberScheduled, found := nested.Int64(obj.Object, "status", "updatedNumberScheduled")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d pods updated", desiredNumberScheduled))
 	}
 
 	// Get status.numberAvailable
-	numberAvailable, found := getInt64Field(obj.Object, "status", "numberAvailable")
+	numberAvailable, found := nested.Int64(obj.Object, "status", "numberAvailable")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d pods available", desiredNumberScheduled))
 	}
 
 	// Check all numbers match
-	return desiredNumberScheduled == numberReady &&
-		updatedNumberScheduled == desiredNumberScheduled &&
-		numberAvailable == desiredNumberScheduled
+	switch {
+	case updatedNumberScheduled != desiredNumberScheduled:
+		return Progressing(fmt.Sprintf("%d/%d pods updated", updatedNumberScheduled, desiredNumberScheduled))
+	case numberReady != desiredNumberScheduled:
+		return Progressing(fmt.Sprintf("%d/%d pods ready", numberReady, desiredNumberScheduled))
+	case numberAvailable != desiredNumberScheduled:
+		return Progressing(fmt.Sprintf("%d/%d pods available", numberAvailable, desiredNumberScheduled))
+	}
+
+	return Healthy(fmt.Sprintf("%d/%d pods available", numberAvailable, desiredNumberScheduled))
 }
@@ -10,7 +10,7 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy daemonset - all pods scheduled and ready",
@@ -26,7 +26,7 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy daemonset - pods not ready",
@@ -42,7 +42,7 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy daemonset - update in progress",
@@ -58,7 +58,7 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy daemonset - pods not available",
@@ -74,7 +74,7 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy daemonset - no status",
@@ -84,15 +84,15 @@ func TestCheckDaemonSetHealth(t *testing.T) {
 					"kind":       "DaemonSet",
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkDaemonSetHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkDaemonSetHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkDaemonSetHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
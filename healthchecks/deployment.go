@@ -1,8 +1,12 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/nested"
 )
 
 func registerDeploymentHealthCheck() {
@@ -19,56 +23,73 @@ func registerDeploymentHealthCheck() {
 // 1. spec.replicas == status.updatedReplicas
 // 2. spec.replicas == status.availableReplicas
 // 3. status.conditions contains "Available" with status "True"
-func checkDeploymentHealth(obj *unstructured.Unstructured) bool {
+// A paused Deployment is healthy once its rollout is complete and suspended
+// until then. One whose Progressing condition reports
+// ProgressDeadlineExceeded is degraded.
+func checkDeploymentHealth(obj *unstructured.Unstructured) HealthStatus {
+	h := checkDeploymentRollout(obj)
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		if h.IsHealthy() {
+			return Healthy(fmt.Sprintf("deployment is paused: %s", h.Message))
+		}
+		return Suspended("deployment is paused")
+	}
+	return h
+}
+
+// checkDeploymentRollout reports how far a Deployment's rollout has got.
+func checkDeploymentRollout(obj *unstructured.Unstructured) HealthStatus {
 	// Get spec.replicas (may be nil, defaults to 1)
 	specReplicas := int64(1)
-	if val, found := getInt64Field(obj.Object, "spec", "replicas"); found {
+	if val, found := nested.Int64(obj.Object, "spec", "replicas"); found {
 		specReplicas = val
 	}
 
+	// Check for conditions
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	available := false
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condType, _, _ := unstructured.NestedString(condMap, "type")
+		condStatus, _, _ := unstructured.NestedString(condMap, "status")
+		condReason, _, _ := unstructured.NestedString(condMap, "reason")
+
+		switch {
+		case condType == "Progressing" && condReason == "ProgressDeadlineExceeded":
+			condMessage, _, _ := unstructured.NestedString(condMap, "message")
+			return Degraded(fmt.Sprintf("progress deadline exceeded: %s", condMessage))
+		case condType == "Available" && condStatus == "True":
+			available = true
+		}
+	}
+
 	// Get status.updatedReplicas
-	updatedReplicas, found := getInt64Field(obj.Object, "status", "updatedReplicas")
+	updatedReplicas, found := nested.Int64(obj.Object, "status", "updatedReplicas")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d replicas updated", specReplicas))
 	}
 
 	// Get status.availableReplicas
-	availableReplicas, found := getInt64Field(obj.Object, "status", "availableReplicas")
+	availableReplicas, found := nested.Int64(obj.Object, "status", "availableReplicas")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d replicas available", specReplicas))
 	}
 
 	// Check replica counts match
-	if specReplicas != updatedReplicas || specReplicas != availableReplicas {
-		return false
+	if specReplicas != updatedReplicas {
+		return Progressing(fmt.Sprintf("%d/%d replicas updated", updatedReplicas, specReplicas))
 	}
-
-	// Check for Available condition
-	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
-	if err != nil || !found {
-		return false
+	if specReplicas != availableReplicas {
+		return Progressing(fmt.Sprintf("%d/%d replicas available", availableReplicas, specReplicas))
 	}
 
-	for _, cond := range conditions {
-		condMap, ok := cond.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		condType, found, err := unstructured.NestedString(condMap, "type")
-		if err != nil || !found || condType != "Available" {
-			continue
-		}
-
-		condStatus, found, err := unstructured.NestedString(condMap, "status")
-		if err != nil || !found {
-			continue
-		}
-
-		if condStatus == "True" {
-			return true
-		}
+	if !available {
+		return Progressing("waiting for Available condition")
 	}
 
-	return false
+	return Healthy(fmt.Sprintf("%d/%d replicas available", availableReplicas, specReplicas))
 }
@@ -10,7 +10,7 @@ func TestCheckDeploymentHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy deployment - all replicas ready",
@@ -33,7 +33,7 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy deployment - replicas not updated",
@@ -56,7 +56,7 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy deployment - replicas not available",
@@ -79,7 +79,7 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy deployment - Available condition False",
@@ -102,7 +102,7 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy deployment - no status",
@@ -115,7 +115,70 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
+		},
+		{
+			name: "degraded deployment - progress deadline exceeded",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"spec": map[string]interface{}{
+						"replicas": int64(3),
+					},
+					"status": map[string]interface{}{
+						"updatedReplicas":   int64(3),
+						"availableReplicas": int64(1),
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":    "Progressing",
+								"status":  "False",
+								"reason":  "ProgressDeadlineExceeded",
+								"message": "ReplicaSet \"web-5d4f\" has timed out progressing.",
+							},
+						},
+					},
+				},
+			},
+			expected: HealthStatusDegraded,
+		},
+		{
+			name: "suspended deployment - paused mid rollout",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"spec": map[string]interface{}{
+						"replicas": int64(3),
+						"paused":   true,
+					},
+				},
+			},
+			expected: HealthStatusSuspended,
+		},
+		{
+			name: "healthy deployment - paused after rollout",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"spec": map[string]interface{}{
+						"replicas": int64(3),
+						"paused":   true,
+					},
+					"status": map[string]interface{}{
+						"updatedReplicas":   int64(3),
+						"availableReplicas": int64(3),
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":   "Available",
+								"status": "True",
+							},
+						},
+					},
+				},
+			},
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy deployment - default replicas (1)",
@@ -136,15 +199,15 @@ func TestCheckDeploymentHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkDeploymentHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkDeploymentHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkDeploymentHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
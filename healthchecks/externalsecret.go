@@ -0,0 +1,17 @@
+package healthchecks
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerExternalSecretsHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "external-secrets.io", Kind: "ExternalSecret"}, checkExternalSecretHealth)
+}
+
+// checkExternalSecretHealth implements ArgoCD-style health check for
+// ExternalSecrets. An ExternalSecret is considered healthy when its Ready
+// condition is True, i.e. its secret was synced, and degraded when it's False.
+func checkExternalSecretHealth(obj *unstructured.Unstructured) HealthStatus {
+	return readyConditionHealth(obj, "externalsecret")
+}
@@ -0,0 +1,11 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckExternalSecretHealth(t *testing.T) {
+	testFixtures(t, checkExternalSecretHealth, []fixtureCase{
+		{name: "synced externalsecret", fixture: "external-secrets.io/ExternalSecret/healthy.yaml", expected: Healthy("secret synced")},
+		{name: "externalsecret that failed to sync", fixture: "external-secrets.io/ExternalSecret/failed.yaml", expected: Degraded("Ready condition is False: SecretSyncedError: could not get secret data from provider")},
+		{name: "new externalsecret", fixture: "external-secrets.io/ExternalSecret/new.yaml", expected: Progressing("waiting for externalsecret Ready condition")},
+	})
+}
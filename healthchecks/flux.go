@@ -0,0 +1,37 @@
+package healthchecks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerFluxHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "kustomize.toolkit.fluxcd.io", Kind: "Kustomization"}, checkFluxHealth)
+	registerCatalogHealthCheck(schema.GroupKind{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"}, checkFluxHealth)
+}
+
+// checkFluxHealth implements health check for Flux Kustomizations and
+// HelmReleases, which follow the kstatus conventions.
+// A Flux resource is considered healthy when its Ready condition is True.
+// A suspended resource is suspended, one with a True Stalled condition is
+// degraded, and one with a True Reconciling condition is progressing.
+func checkFluxHealth(obj *unstructured.Unstructured) HealthStatus {
+	kind := strings.ToLower(obj.GetKind())
+
+	if suspend, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspend {
+		return Suspended(fmt.Sprintf("%s is suspended", kind))
+	}
+
+	if c, found := getCondition(obj, "Stalled"); found && c.Status == "True" {
+		return Degraded(conditionMessage(c))
+	}
+
+	if c, found := getCondition(obj, "Reconciling"); found && c.Status == "True" {
+		return Progressing(conditionMessage(c))
+	}
+
+	return readyConditionHealth(obj, kind)
+}
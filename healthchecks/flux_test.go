@@ -0,0 +1,15 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckFluxHealth(t *testing.T) {
+	testFixtures(t, checkFluxHealth, []fixtureCase{
+		{name: "healthy kustomization", fixture: "kustomize.toolkit.fluxcd.io/Kustomization/healthy.yaml", expected: Healthy("Applied revision: main@sha1:1a2b3c")},
+		{name: "reconciling kustomization", fixture: "kustomize.toolkit.fluxcd.io/Kustomization/reconciling.yaml", expected: Progressing("Reconciling condition is True: ProgressingWithRetry: Detecting drift for revision main@sha1:4d5e6f")},
+		{name: "failed kustomization", fixture: "kustomize.toolkit.fluxcd.io/Kustomization/failed.yaml", expected: Degraded("Ready condition is False: BuildFailed: kustomization path not found")},
+		{name: "suspended kustomization", fixture: "kustomize.toolkit.fluxcd.io/Kustomization/suspended.yaml", expected: Suspended("kustomization is suspended")},
+		{name: "kustomization with stale status", fixture: "kustomize.toolkit.fluxcd.io/Kustomization/stale.yaml", expected: Progressing("waiting for kustomization spec update to be observed")},
+		{name: "healthy helmrelease", fixture: "helm.toolkit.fluxcd.io/HelmRelease/healthy.yaml", expected: Healthy("Helm install succeeded for release default/podinfo.v1 with chart podinfo@6.5.4")},
+		{name: "stalled helmrelease", fixture: "helm.toolkit.fluxcd.io/HelmRelease/stalled.yaml", expected: Degraded("Stalled condition is True: RetriesExceeded: Failed to install after 3 attempt(s)")},
+	})
+}
@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,11 +19,11 @@ func registerHorizontalPodAutoscalerHealthCheck() {
 
 // checkHorizontalPodAutoscalerHealth implements health check for HorizontalPodAutoscalers
 // Based on ArgoCD's gitops-engine implementation
-func checkHorizontalPodAutoscalerHealth(obj *unstructured.Unstructured) bool {
+func checkHorizontalPodAutoscalerHealth(obj *unstructured.Unstructured) HealthStatus {
 	var hpa autoscalingv2.HorizontalPodAutoscaler
 	err := convertFromUnstructured(obj, &hpa)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to HorizontalPodAutoscaler: %s", err))
 	}
 
 	for _, condition := range hpa.Status.Conditions {
@@ -29,7 +31,7 @@ func checkHorizontalPodAutoscalerHealth(obj *unstructured.Unstructured) bool {
 		switch condition.Type {
 		case "FailedGetScale", "FailedUpdateScale", "FailedGetResourceMetric", "InvalidSelector":
 			if condition.Status == "True" {
-				return false
+				return Degraded(fmt.Sprintf("%s: %s", condition.Type, condition.Message))
 			}
 		}
 
@@ -37,15 +39,15 @@ func checkHorizontalPodAutoscalerHealth(obj *unstructured.Unstructured) bool {
 		switch condition.Type {
 		case autoscalingv2.ScalingActive:
 			if condition.Status == "True" {
-				return true
+				return Healthy(condition.Message)
 			}
 		case autoscalingv2.ScalingLimited:
 			if condition.Status == "True" {
-				return true
+				return Healthy(condition.Message)
 			}
 		}
 	}
 
 	// Progressing (waiting to autoscale)
-	return false
+	return Progressing("waiting to autoscale")
 }
@@ -10,7 +10,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy hpa - scaling active",
@@ -28,7 +28,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy hpa - scaling limited",
@@ -46,7 +46,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy hpa - failed to get scale",
@@ -64,7 +64,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy hpa - failed to update scale",
@@ -82,7 +82,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy hpa - invalid selector",
@@ -100,7 +100,7 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "progressing hpa - no conditions",
@@ -111,15 +111,15 @@ func TestCheckHorizontalPodAutoscalerHealth(t *testing.T) {
 					"status":     map[string]interface{}{},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkHorizontalPodAutoscalerHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkHorizontalPodAutoscalerHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkHorizontalPodAutoscalerHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
@@ -17,13 +17,13 @@ func registerIngressHealthCheck() {
 // checkIngressHealth implements ArgoCD-style health check for Ingresses
 // An Ingress is considered healthy when:
 // - status.loadBalancer.ingress is populated (LoadBalancer has been assigned)
-func checkIngressHealth(obj *unstructured.Unstructured) bool {
+func checkIngressHealth(obj *unstructured.Unstructured) HealthStatus {
 	// Check for status.loadBalancer.ingress
 	ingress, found, err := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
-	if err != nil || !found {
-		return false
+	if err != nil || !found || len(ingress) == 0 {
+		return Progressing("waiting for load balancer ingress")
 	}
 
 	// Ingress is healthy if at least one ingress point is assigned
-	return len(ingress) > 0
+	return Healthy("")
 }
@@ -10,7 +10,7 @@ func TestCheckIngressHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy ingress - loadbalancer assigned",
@@ -29,7 +29,7 @@ func TestCheckIngressHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy ingress - hostname assigned",
@@ -48,7 +48,7 @@ func TestCheckIngressHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy ingress - no loadbalancer",
@@ -61,7 +61,7 @@ func TestCheckIngressHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy ingress - no status",
@@ -71,15 +71,15 @@ func TestCheckIngressHealth(t *testing.T) {
 					"kind":       "Ingress",
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkIngressHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkIngressHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkIngressHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,37 @@
+package healthchecks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerIstioHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"}, checkVirtualServiceHealth)
+}
+
+// checkVirtualServiceHealth implements health check for Istio
+// VirtualServices. VirtualServices don't have a Ready condition, so a
+// VirtualService is considered healthy once it exists, unless Istio's analysis
+// reported an error in status.validationMessages.
+func checkVirtualServiceHealth(obj *unstructured.Unstructured) HealthStatus {
+	messages, _, _ := unstructured.NestedSlice(obj.Object, "status", "validationMessages")
+	var errs []string
+	for _, m := range messages {
+		msgMap, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if level, _, _ := unstructured.NestedString(msgMap, "level"); level != "ERROR" {
+			continue
+		}
+		code, _, _ := unstructured.NestedString(msgMap, "type", "code")
+		errs = append(errs, code)
+	}
+	if len(errs) > 0 {
+		return Degraded(fmt.Sprintf("validation errors: %s", strings.Join(errs, ", ")))
+	}
+	return Healthy("")
+}
@@ -0,0 +1,10 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckVirtualServiceHealth(t *testing.T) {
+	testFixtures(t, checkVirtualServiceHealth, []fixtureCase{
+		{name: "virtualservice without status", fixture: "networking.istio.io/VirtualService/healthy.yaml", expected: Healthy("")},
+		{name: "virtualservice with validation errors", fixture: "networking.istio.io/VirtualService/invalid.yaml", expected: Degraded("validation errors: IST0101")},
+	})
+}
@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,30 +19,30 @@ func registerJobHealthCheck() {
 
 // checkJobHealth implements health check for Jobs
 // Based on ArgoCD's gitops-engine implementation
-func checkJobHealth(obj *unstructured.Unstructured) bool {
+func checkJobHealth(obj *unstructured.Unstructured) HealthStatus {
 	var job batchv1.Job
 	err := convertFromUnstructured(obj, &job)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to Job: %s", err))
 	}
 
 	for _, condition := range job.Status.Conditions {
 		switch condition.Type {
 		case batchv1.JobFailed:
 			if condition.Status == "True" {
-				return false
+				return Degraded(fmt.Sprintf("job failed: %s", condition.Message))
 			}
 		case batchv1.JobSuspended:
 			if condition.Status == "True" {
-				return false
+				return Suspended("job is suspended")
 			}
 		case batchv1.JobComplete:
 			if condition.Status == "True" {
-				return true
+				return Healthy(condition.Message)
 			}
 		}
 	}
 
 	// Job is still progressing
-	return false
+	return Progressing("job is running")
 }
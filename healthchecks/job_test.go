@@ -10,7 +10,7 @@ func TestCheckJobHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy job - completed",
@@ -28,7 +28,7 @@ func TestCheckJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy job - failed",
@@ -46,7 +46,7 @@ func TestCheckJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy job - suspended",
@@ -64,7 +64,7 @@ func TestCheckJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusSuspended,
 		},
 		{
 			name: "progressing job - no conditions",
@@ -75,7 +75,7 @@ func TestCheckJobHealth(t *testing.T) {
 					"status":     map[string]interface{}{},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "progressing job - condition false",
@@ -93,15 +93,15 @@ func TestCheckJobHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkJobHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkJobHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkJobHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
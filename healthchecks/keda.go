@@ -0,0 +1,21 @@
+package healthchecks
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerKEDAHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "keda.sh", Kind: "ScaledObject"}, checkScaledObjectHealth)
+}
+
+// checkScaledObjectHealth implements ArgoCD-style health check for KEDA
+// ScaledObjects. A ScaledObject is considered healthy when its Ready condition
+// is True, and degraded when it's False. A paused ScaledObject is suspended.
+// Whether it's Active, i.e. currently scaling, doesn't affect its health.
+func checkScaledObjectHealth(obj *unstructured.Unstructured) HealthStatus {
+	if c, found := getCondition(obj, "Paused"); found && c.Status == "True" {
+		return Suspended("scaledobject is paused")
+	}
+	return readyConditionHealth(obj, "scaledobject")
+}
@@ -0,0 +1,11 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckScaledObjectHealth(t *testing.T) {
+	testFixtures(t, checkScaledObjectHealth, []fixtureCase{
+		{name: "ready scaledobject", fixture: "keda.sh/ScaledObject/healthy.yaml", expected: Healthy("ScaledObject is defined correctly and is ready for scaling")},
+		{name: "paused scaledobject", fixture: "keda.sh/ScaledObject/paused.yaml", expected: Suspended("scaledobject is paused")},
+		{name: "failed scaledobject", fixture: "keda.sh/ScaledObject/failed.yaml", expected: Degraded("Ready condition is False: ScaledObjectCheckFailed: ScaledObject doesn't have correct scaleTargetRef specification")},
+	})
+}
@@ -0,0 +1,17 @@
+package healthchecks
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerKnativeHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "serving.knative.dev", Kind: "Service"}, checkKnativeServiceHealth)
+}
+
+// checkKnativeServiceHealth implements ArgoCD-style health check for Knative
+// Services. A Knative Service is considered healthy when its Ready condition
+// is True, and degraded when it's False.
+func checkKnativeServiceHealth(obj *unstructured.Unstructured) HealthStatus {
+	return readyConditionHealth(obj, "knative service")
+}
@@ -0,0 +1,11 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckKnativeServiceHealth(t *testing.T) {
+	testFixtures(t, checkKnativeServiceHealth, []fixtureCase{
+		{name: "ready service", fixture: "serving.knative.dev/Service/healthy.yaml", expected: Healthy("")},
+		{name: "service waiting for revision", fixture: "serving.knative.dev/Service/progressing.yaml", expected: Progressing(`Ready condition is Unknown: RevisionMissing: Configuration "hello" is waiting for a Revision to become ready.`)},
+		{name: "service with failed revision", fixture: "serving.knative.dev/Service/failed.yaml", expected: Degraded(`Ready condition is False: RevisionFailed: Revision "hello-00001" failed with message: Unable to fetch image "example.org/hello".`)},
+	})
+}
@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,21 +19,21 @@ func registerPersistentVolumeClaimHealthCheck() {
 
 // checkPersistentVolumeClaimHealth implements health check for PersistentVolumeClaims
 // Based on ArgoCD's gitops-engine implementation
-func checkPersistentVolumeClaimHealth(obj *unstructured.Unstructured) bool {
+func checkPersistentVolumeClaimHealth(obj *unstructured.Unstructured) HealthStatus {
 	var pvc corev1.PersistentVolumeClaim
 	err := convertFromUnstructured(obj, &pvc)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to PersistentVolumeClaim: %s", err))
 	}
 
 	switch pvc.Status.Phase {
 	case corev1.ClaimBound:
-		return true
+		return Healthy("")
 	case corev1.ClaimLost:
-		return false
+		return Degraded("claim lost its underlying volume")
 	case corev1.ClaimPending:
-		return false
+		return Progressing("claim is pending")
 	default:
-		return false
+		return Progressing("waiting for claim to be bound")
 	}
 }
@@ -10,7 +10,7 @@ func TestCheckPersistentVolumeClaimHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy pvc - bound",
@@ -23,7 +23,7 @@ func TestCheckPersistentVolumeClaimHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy pvc - lost",
@@ -36,7 +36,7 @@ func TestCheckPersistentVolumeClaimHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "progressing pvc - pending",
@@ -49,7 +49,7 @@ func TestCheckPersistentVolumeClaimHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unknown pvc - no status",
@@ -59,15 +59,15 @@ func TestCheckPersistentVolumeClaimHealth(t *testing.T) {
 					"kind":       "PersistentVolumeClaim",
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkPersistentVolumeClaimHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkPersistentVolumeClaimHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkPersistentVolumeClaimHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
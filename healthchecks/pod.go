@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,23 +19,23 @@ func registerPodHealthCheck() {
 
 // checkPodHealth implements health check for Pods
 // Based on ArgoCD's gitops-engine implementation
-func checkPodHealth(obj *unstructured.Unstructured) bool {
+func checkPodHealth(obj *unstructured.Unstructured) HealthStatus {
 	var pod corev1.Pod
 	err := convertFromUnstructured(obj, &pod)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to Pod: %s", err))
 	}
 
 	switch pod.Status.Phase {
 	case corev1.PodSucceeded:
-		return true
+		return Healthy(pod.Status.Message)
 	case corev1.PodRunning:
 		// For pods with RestartPolicy Always, check if ready
 		if pod.Spec.RestartPolicy == corev1.RestartPolicyAlways {
 			// Check if pod is ready
 			for _, condition := range pod.Status.Conditions {
 				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-					return true
+					return Healthy(pod.Status.Message)
 				}
 			}
 			// Check for container failures
@@ -45,23 +47,26 @@ func checkPodHealth(obj *unstructured.Unstructured) bool {
 						waiting.Reason == "ErrImagePull" ||
 						waiting.Reason == "CrashLoopBackOff" ||
 						waiting.Reason == "CreateContainerConfigError" {
-						return false
+						return Degraded(fmt.Sprintf("container %s: %s", containerStatus.Name, waiting.Reason))
 					}
 				}
 				if containerStatus.State.Terminated != nil {
-					return false
+					return Degraded(fmt.Sprintf("container %s terminated: %s", containerStatus.Name, containerStatus.State.Terminated.Reason))
 				}
 			}
 			// Pod is running but not ready yet
-			return false
+			return Progressing("pod is running but not ready")
 		}
 		// For OnFailure/Never restart policies, running means progressing
-		return false
+		return Progressing("pod is running")
 	case corev1.PodFailed:
-		return false
+		if pod.Status.Message != "" {
+			return Degraded(pod.Status.Message)
+		}
+		return Degraded("pod failed")
 	case corev1.PodPending:
-		return false
+		return Progressing("pod is pending")
 	default:
-		return false
+		return Progressing(fmt.Sprintf("pod phase is %q", pod.Status.Phase))
 	}
 }
@@ -10,7 +10,7 @@ func TestCheckPodHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy pod - running and ready",
@@ -32,7 +32,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy pod - succeeded",
@@ -48,7 +48,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy pod - failed",
@@ -64,7 +64,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy pod - pending",
@@ -80,7 +80,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy pod - ImagePullBackOff",
@@ -105,7 +105,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy pod - CrashLoopBackOff",
@@ -130,7 +130,7 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "progressing pod - running with OnFailure restart policy",
@@ -146,15 +146,15 @@ func TestCheckPodHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkPodHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkPodHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkPodHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
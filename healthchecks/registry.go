@@ -6,9 +6,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// HealthCheckFunc is a function that determines if a Kubernetes resource is healthy/ready.
-// It returns true if the resource is ready, false otherwise.
-type HealthCheckFunc func(obj *unstructured.Unstructured) bool
+// HealthCheckFunc is a function that determines the health of a Kubernetes resource.
+// It returns a HealthStatus describing whether the resource is healthy, still
+// progressing, degraded or suspended, along with a human-readable message.
+type HealthCheckFunc func(obj *unstructured.Unstructured) HealthStatus
 
 // registry holds the mapping from GroupVersionKind to health check functions
 var registry = make(map[schema.GroupVersionKind]HealthCheckFunc)
@@ -27,8 +28,8 @@ func GetHealthCheck(gvk schema.GroupVersionKind) HealthCheckFunc {
 // alwaysReady is a health check function for resources that are considered ready
 // if they exist in the observed state (e.g., ConfigMap, Secret, ServiceAccount, Namespace).
 // These resources don't have meaningful status conditions to check.
-func alwaysReady(obj *unstructured.Unstructured) bool {
-	return true
+func alwaysReady(obj *unstructured.Unstructured) HealthStatus {
+	return Healthy("")
 }
 
 // convertFromUnstructured converts an unstructured object to a typed Kubernetes object
@@ -36,28 +37,11 @@ func convertFromUnstructured(obj *unstructured.Unstructured, target interface{})
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, target)
 }
 
-// getInt64Field extracts an int64 value from a field, handling multiple numeric types
-func getInt64Field(obj map[string]interface{}, path ...string) (int64, bool) {
-	val, found, err := unstructured.NestedFieldNoCopy(obj, path...)
-	if err != nil || !found || val == nil {
-		return 0, false
-	}
-
-	switch v := val.(type) {
-	case int64:
-		return v, true
-	case int:
-		return int64(v), true
-	case float64:
-		return int64(v), true
-	default:
-		return 0, false
-	}
-}
-
 func init() {
 	// Register all standard Kubernetes resource health checks
 	registerConfigMapHealthCheck()
+	registerCrossplaneAPIExtensionsHealthChecks()
+	registerCrossplanePackageHealthChecks()
 	registerCronJobHealthCheck()
 	registerDaemonSetHealthCheck()
 	registerDeploymentHealthCheck()
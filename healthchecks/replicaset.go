@@ -1,6 +1,8 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,22 +19,22 @@ func registerReplicaSetHealthCheck() {
 
 // checkReplicaSetHealth implements health check for ReplicaSets
 // Based on ArgoCD's gitops-engine implementation
-func checkReplicaSetHealth(obj *unstructured.Unstructured) bool {
+func checkReplicaSetHealth(obj *unstructured.Unstructured) HealthStatus {
 	var rs appsv1.ReplicaSet
 	err := convertFromUnstructured(obj, &rs)
 	if err != nil {
-		return false
+		return Degraded(fmt.Sprintf("cannot convert to ReplicaSet: %s", err))
 	}
 
 	// Check if observed generation matches
 	if rs.Status.ObservedGeneration < rs.Generation {
-		return false
+		return Progressing("waiting for rollout to be observed")
 	}
 
 	// Check for replica failure condition
 	for _, condition := range rs.Status.Conditions {
 		if condition.Type == appsv1.ReplicaSetReplicaFailure && condition.Status == "True" {
-			return false
+			return Degraded(condition.Message)
 		}
 	}
 
@@ -43,8 +45,8 @@ func checkReplicaSetHealth(obj *unstructured.Unstructured) bool {
 	}
 
 	if rs.Status.AvailableReplicas < desiredReplicas {
-		return false
+		return Progressing(fmt.Sprintf("%d/%d replicas available", rs.Status.AvailableReplicas, desiredReplicas))
 	}
 
-	return true
+	return Healthy(fmt.Sprintf("%d/%d replicas available", rs.Status.AvailableReplicas, desiredReplicas))
 }
@@ -10,7 +10,7 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy replicaset - all replicas available",
@@ -30,7 +30,7 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy replicaset - observed generation mismatch",
@@ -50,7 +50,7 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy replicaset - replica failure",
@@ -76,7 +76,7 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusDegraded,
 		},
 		{
 			name: "unhealthy replicaset - not enough available replicas",
@@ -96,7 +96,7 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "healthy replicaset - default replicas (1)",
@@ -114,15 +114,15 @@ func TestCheckReplicaSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkReplicaSetHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkReplicaSetHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkReplicaSetHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
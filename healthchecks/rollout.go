@@ -0,0 +1,50 @@
+package healthchecks
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func registerRolloutHealthChecks() {
+	registerCatalogHealthCheck(schema.GroupKind{Group: "argoproj.io", Kind: "Rollout"}, checkRolloutHealth)
+}
+
+// checkRolloutHealth implements ArgoCD-style health check for Argo Rollouts.
+// Rollouts report their health as status.phase, which is one of Healthy,
+// Progressing, Degraded or Paused. A paused Rollout is suspended, and one with
+// an invalid spec is degraded.
+func checkRolloutHealth(obj *unstructured.Unstructured) HealthStatus {
+	if c, found := getCondition(obj, "InvalidSpec"); found && c.Status == "True" {
+		return Degraded(fmt.Sprintf("invalid spec: %s", c.Message))
+	}
+
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		return Suspended("rollout is paused")
+	}
+
+	// Older Rollouts record a hash of the spec as observedGeneration, which
+	// generationObserved ignores.
+	if !generationObserved(obj) {
+		return Progressing("waiting for rollout spec update to be observed")
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	message, _, _ := unstructured.NestedString(obj.Object, "status", "message")
+	switch phase {
+	case "Healthy":
+		return Healthy(message)
+	case "Degraded":
+		return Degraded(message)
+	case "Paused":
+		if message == "" {
+			message = "rollout is paused"
+		}
+		return Suspended(message)
+	case "":
+		return Progressing("waiting for rollout status")
+	default:
+		return Progressing(message)
+	}
+}
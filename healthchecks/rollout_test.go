@@ -0,0 +1,13 @@
+package healthchecks
+
+import "testing"
+
+func TestCheckRolloutHealth(t *testing.T) {
+	testFixtures(t, checkRolloutHealth, []fixtureCase{
+		{name: "healthy rollout", fixture: "argoproj.io/Rollout/healthy.yaml", expected: Healthy("")},
+		{name: "progressing rollout", fixture: "argoproj.io/Rollout/progressing.yaml", expected: Progressing("more replicas need to be updated")},
+		{name: "paused rollout", fixture: "argoproj.io/Rollout/paused.yaml", expected: Suspended("CanaryPauseStep")},
+		{name: "rollout with invalid spec", fixture: "argoproj.io/Rollout/invalid-spec.yaml", expected: Degraded(`invalid spec: The Rollout "web" is invalid`)},
+		{name: "degraded rollout", fixture: "argoproj.io/Rollout/degraded.yaml", expected: Degraded(`ProgressDeadlineExceeded: ReplicaSet "web-5d8f7c" has timed out progressing.`)},
+	})
+}
@@ -18,7 +18,7 @@ func registerServiceHealthCheck() {
 // A Service is considered healthy when:
 // - If type is LoadBalancer: status.loadBalancer.ingress must be populated
 // - For all other types: always healthy (ClusterIP, NodePort, ExternalName)
-func checkServiceHealth(obj *unstructured.Unstructured) bool {
+func checkServiceHealth(obj *unstructured.Unstructured) HealthStatus {
 	// Get spec.type (defaults to ClusterIP if not specified)
 	serviceType, found, err := unstructured.NestedString(obj.Object, "spec", "type")
 	if err != nil || !found {
@@ -27,15 +27,15 @@ func checkServiceHealth(obj *unstructured.Unstructured) bool {
 
 	// Only LoadBalancer services need health checking
 	if serviceType != "LoadBalancer" {
-		return true
+		return Healthy("")
 	}
 
 	// Check for status.loadBalancer.ingress
 	ingress, found, err := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
-	if err != nil || !found {
-		return false
+	if err != nil || !found || len(ingress) == 0 {
+		return Progressing("waiting for load balancer ingress")
 	}
 
 	// LoadBalancer is healthy if at least one ingress point is assigned
-	return len(ingress) > 0
+	return Healthy("")
 }
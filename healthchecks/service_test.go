@@ -10,7 +10,7 @@ func TestCheckServiceHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy loadbalancer - ingress assigned",
@@ -32,7 +32,7 @@ func TestCheckServiceHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy loadbalancer - no ingress",
@@ -48,7 +48,7 @@ func TestCheckServiceHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "healthy clusterip - always ready",
@@ -61,7 +61,7 @@ func TestCheckServiceHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy nodeport - always ready",
@@ -74,7 +74,7 @@ func TestCheckServiceHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "healthy service - default type (ClusterIP)",
@@ -85,15 +85,15 @@ func TestCheckServiceHealth(t *testing.T) {
 					"spec":       map[string]interface{}{},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkServiceHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkServiceHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkServiceHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
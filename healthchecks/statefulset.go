@@ -1,8 +1,12 @@
 package healthchecks
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/nested"
 )
 
 func registerStatefulSetHealthCheck() {
@@ -19,42 +23,49 @@ func registerStatefulSetHealthCheck() {
 // 1. status.currentRevision == status.updateRevision (all pods updated)
 // 2. spec.replicas == status.readyReplicas (all replicas ready)
 // 3. spec.replicas == status.currentReplicas (all replicas at current revision)
-func checkStatefulSetHealth(obj *unstructured.Unstructured) bool {
+func checkStatefulSetHealth(obj *unstructured.Unstructured) HealthStatus {
 	// Get spec.replicas (may be nil, defaults to 1)
 	specReplicas := int64(1)
-	if val, found := getInt64Field(obj.Object, "spec", "replicas"); found {
+	if val, found := nested.Int64(obj.Object, "spec", "replicas"); found {
 		specReplicas = val
 	}
 
 	// Get status.readyReplicas
-	readyReplicas, found := getInt64Field(obj.Object, "status", "readyReplicas")
+	readyReplicas, found := nested.Int64(obj.Object, "status", "readyReplicas")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d replicas ready", specReplicas))
 	}
 
 	// Get status.currentReplicas
-	currentReplicas, found := getInt64Field(obj.Object, "status", "currentReplicas")
+	currentReplicas, found := nested.Int64(obj.Object, "status", "currentReplicas")
 	if !found {
-		return false
+		return Progressing(fmt.Sprintf("0/%d replicas at current revision", specReplicas))
 	}
 
 	// Check replica counts match
-	if specReplicas != readyReplicas || specReplicas != currentReplicas {
-		return false
+	if specReplicas != readyReplicas {
+		return Progressing(fmt.Sprintf("%d/%d replicas ready", readyReplicas, specReplicas))
+	}
+	if specReplicas != currentReplicas {
+		return Progressing(fmt.Sprintf("%d/%d replicas at current revision", currentReplicas, specReplicas))
 	}
 
 	// Get status.currentRevision
 	currentRevision, found, err := unstructured.NestedString(obj.Object, "status", "currentRevision")
 	if err != nil || !found {
-		return false
+		return Progressing("waiting for current revision")
 	}
 
 	// Get status.updateRevision
 	updateRevision, found, err := unstructured.NestedString(obj.Object, "status", "updateRevision")
 	if err != nil || !found {
-		return false
+		return Progressing("waiting for update revision")
 	}
 
 	// Check that all pods are at the updated revision
-	return currentRevision == updateRevision
+	if currentRevision != updateRevision {
+		return Progressing(fmt.Sprintf("rolling update from revision %s to %s in progress", currentRevision, updateRevision))
+	}
+
+	return Healthy(fmt.Sprintf("%d/%d replicas ready", readyReplicas, specReplicas))
 }
@@ -10,7 +10,7 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 	tests := []struct {
 		name     string
 		obj      *unstructured.Unstructured
-		expected bool
+		expected HealthStatusCode
 	}{
 		{
 			name: "healthy statefulset - all replicas ready and updated",
@@ -29,7 +29,7 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: HealthStatusHealthy,
 		},
 		{
 			name: "unhealthy statefulset - replicas not ready",
@@ -48,7 +48,7 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy statefulset - update in progress",
@@ -67,7 +67,7 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy statefulset - wrong current replicas",
@@ -86,7 +86,7 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 		{
 			name: "unhealthy statefulset - no status",
@@ -99,15 +99,15 @@ func TestCheckStatefulSetHealth(t *testing.T) {
 					},
 				},
 			},
-			expected: false,
+			expected: HealthStatusProgressing,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := checkStatefulSetHealth(tt.obj)
-			if result != tt.expected {
-				t.Errorf("checkStatefulSetHealth() = %v, want %v", result, tt.expected)
+			if result.Status != tt.expected {
+				t.Errorf("checkStatefulSetHealth() = %v, want %v", result.Status, tt.expected)
 			}
 		})
 	}
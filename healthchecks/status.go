@@ -0,0 +1,52 @@
+package healthchecks
+
+// HealthStatusCode represents the health of a Kubernetes resource.
+// The codes are modelled on Argo CD's health statuses.
+type HealthStatusCode string
+
+const (
+	// HealthStatusHealthy indicates the resource is healthy and ready.
+	HealthStatusHealthy HealthStatusCode = "Healthy"
+	// HealthStatusProgressing indicates the resource is not healthy yet, but
+	// is still making progress and might become healthy without intervention.
+	HealthStatusProgressing HealthStatusCode = "Progressing"
+	// HealthStatusDegraded indicates the resource has failed, or has been
+	// unable to make progress, and is unlikely to become healthy on its own.
+	HealthStatusDegraded HealthStatusCode = "Degraded"
+	// HealthStatusSuspended indicates the resource is paused or suspended
+	// and is waiting for some external event to resume.
+	HealthStatusSuspended HealthStatusCode = "Suspended"
+)
+
+// HealthStatus is the result of a health check.
+type HealthStatus struct {
+	// Status is the health status code of the resource.
+	Status HealthStatusCode
+	// Message is a human-readable explanation of the status.
+	Message string
+}
+
+// IsHealthy returns true if the status is HealthStatusHealthy.
+func (h HealthStatus) IsHealthy() bool {
+	return h.Status == HealthStatusHealthy
+}
+
+// Healthy returns a HealthStatus with HealthStatusHealthy and the supplied message.
+func Healthy(message string) HealthStatus {
+	return HealthStatus{Status: HealthStatusHealthy, Message: message}
+}
+
+// Progressing returns a HealthStatus with HealthStatusProgressing and the supplied message.
+func Progressing(message string) HealthStatus {
+	return HealthStatus{Status: HealthStatusProgressing, Message: message}
+}
+
+// Degraded returns a HealthStatus with HealthStatusDegraded and the supplied message.
+func Degraded(message string) HealthStatus {
+	return HealthStatus{Status: HealthStatusDegraded, Message: message}
+}
+
+// Suspended returns a HealthStatus with HealthStatusSuspended and the supplied message.
+func Suspended(message string) HealthStatus {
+	return HealthStatus{Status: HealthStatusSuspended, Message: message}
+}
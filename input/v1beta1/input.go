@@ -35,4 +35,453 @@ type Input struct {
 	// CELHealthCheckCustomizationFrom is a reference to fetch CEL health check customizations from context
 	// +kubebuilder:validation:Optional
 	CELHealthCheckCustomizationFrom *string `json:"celHealthCheckCustomizationFrom,omitempty"`
+
+	// CELHealthCheckResourceOverrides are CEL health check customizations
+	// keyed by composition resource name. They take precedence over
+	// customizations keyed by GVK and over built-in health checks. They're
+	// ignored with a warning unless the CELHealthcheckCustomizations alpha
+	// feature is enabled.
+	// +optional
+	CELHealthCheckResourceOverrides map[string]string `json:"celHealthCheckResourceOverrides,omitempty"`
+
+	// CELHealthCheckRules are CEL health checks with per-rule options. They
+	// are considered together with CELHealthCheckCustomization, which is
+	// converted to rules with priority 0. CELHealthCheckResourceOverrides
+	// take precedence over every rule. Otherwise rules with a higher priority
+	// take precedence, then rules that select specific composed resources,
+	// then rules with an exact kind, group and version, then the first rule.
+	// They're ignored with a warning unless the CELHealthcheckCustomizations
+	// alpha feature is enabled.
+	// +optional
+	CELHealthCheckRules []CELHealthCheckRule `json:"celHealthCheckRules,omitempty"`
+
+	// CELValidationPolicy controls how invalid CEL health check
+	// customizations are reported. Every customization is compiled before
+	// any composed resource is evaluated. Fatal fails the Function, while
+	// Warning emits a single Warning result and ignores the invalid
+	// customizations.
+	// +kubebuilder:validation:Enum=Fatal;Warning
+	// +kubebuilder:default=Warning
+	// +optional
+	CELValidationPolicy CELValidationPolicy `json:"celValidationPolicy,omitempty"`
+
+	// CELCostLimit limits the runtime cost of evaluating each CEL
+	// expression. Evaluations exceeding it fail. It can't exceed the limit
+	// the Function was started with.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CELCostLimit *int64 `json:"celCostLimit,omitempty"`
+
+	// CELEvaluationTimeout limits how long each CEL expression may be
+	// evaluated for, in time.Duration format. Evaluations exceeding it fail.
+	// It can't exceed the timeout the Function was started with.
+	// +optional
+	CELEvaluationTimeout string `json:"celEvaluationTimeout,omitempty"`
+
+	// LuaHealthCheckCustomization is an inline map of Argo CD style Lua
+	// health check scripts keyed by <group>_<kind>. Keys may use the
+	// resource.customizations.health. prefix of Argo CD's argocd-cm
+	// ConfigMap. Inline scripts take precedence over scripts from context.
+	// +optional
+	LuaHealthCheckCustomization map[string]string `json:"luaHealthCheckCustomization,omitempty"`
+
+	// LuaHealthCheckCustomizationFrom is a reference to fetch Lua health
+	// check scripts from context.
+	// +optional
+	LuaHealthCheckCustomizationFrom *string `json:"luaHealthCheckCustomizationFrom,omitempty"`
+
+	// LuaInstructionLimit limits approximately how many instructions each Lua
+	// health check script may execute. Scripts exceeding it fail. It can't exceed the
+	// limit the Function was started with.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LuaInstructionLimit *int64 `json:"luaInstructionLimit,omitempty"`
+
+	// LuaEvaluationTimeout limits how long each Lua health check script may
+	// run for, in time.Duration format. Scripts exceeding it fail. It can't
+	// exceed the timeout the Function was started with.
+	// +optional
+	LuaEvaluationTimeout string `json:"luaEvaluationTimeout,omitempty"`
+
+	// HealthCheckCatalog enables the bundled health checks for popular CRDs
+	// in the supplied API groups. Supported groups are argoproj.io,
+	// external-secrets.io, helm.toolkit.fluxcd.io, keda.sh,
+	// kustomize.toolkit.fluxcd.io, networking.istio.io, postgresql.cnpg.io
+	// and serving.knative.dev.
+	// +optional
+	HealthCheckCatalog []string `json:"healthCheckCatalog,omitempty"`
+
+	// ResultTarget controls whether the results and the ResourcesHealthy
+	// condition emitted for composed resources that are not healthy target
+	// only the composite resource, or both the composite resource and its claim.
+	// +kubebuilder:validation:Enum=Composite;CompositeAndClaim
+	// +kubebuilder:default=Composite
+	// +optional
+	ResultTarget Target `json:"resultTarget,omitempty"`
+
+	// UnhealthyPolicy controls the readiness written for composed resources
+	// whose built-in or CEL health check reports them Degraded. A CEL health
+	// check that evaluates to false reports the resource Degraded.
+	// Unspecified leaves their readiness unspecified, while False explicitly
+	// marks them not ready. If unset, resources reported Degraded by a CEL
+	// health check are marked not ready and all others are left unspecified.
+	// +kubebuilder:validation:Enum=Unspecified;False
+	// +optional
+	UnhealthyPolicy UnhealthyPolicy `json:"unhealthyPolicy,omitempty"`
+
+	// RequireSynced requires composed resources that are checked using their
+	// Ready condition to have a True Synced condition too, and nested
+	// composite resources to have a True Responsive condition. A resource
+	// whose Synced condition is False with reason ReconcileError is
+	// degraded. Resources without these conditions are unaffected.
+	// +optional
+	RequireSynced bool `json:"requireSynced,omitempty"`
+
+	// StaleStatusPolicy controls how composed resources whose status doesn't
+	// reflect their latest spec yet are handled. A status is stale when
+	// status.observedGeneration, or the observedGeneration of the Ready
+	// condition, is behind metadata.generation. Progressing reports such
+	// resources progressing before any health check runs, while Ignore
+	// evaluates their health checks regardless.
+	// +kubebuilder:validation:Enum=Progressing;Ignore
+	// +kubebuilder:default=Progressing
+	// +optional
+	StaleStatusPolicy StaleStatusPolicy `json:"staleStatusPolicy,omitempty"`
+
+	// StaleStatusPolicyOverrides overrides the stale status policy for
+	// specific kinds of composed resource, keyed by
+	// <group>_<version>_<kind>. The version and kind may be *.
+	// +optional
+	StaleStatusPolicyOverrides map[string]StaleStatusPolicy `json:"staleStatusPolicyOverrides,omitempty"`
+
+	// Include restricts the composed resources this Function evaluates to
+	// those matching at least one selector. All composed resources are
+	// evaluated when Include is empty.
+	// +optional
+	Include []ResourceSelector `json:"include,omitempty"`
+
+	// Exclude skips composed resources matching any selector, even if they
+	// are matched by Include.
+	// +optional
+	Exclude []ResourceSelector `json:"exclude,omitempty"`
+
+	// OptionalResources selects composed resources that never block the
+	// readiness of the composite resource. Composed resources annotated with
+	// autoready.fn.crossplane.io/optional: "true" are optional too. Optional
+	// resources are marked ready regardless of their health, which is still
+	// reported as a Warning result.
+	// +optional
+	OptionalResources []ResourceSelector `json:"optionalResources,omitempty"`
+
+	// OptionalResourcePolicy controls when optional composed resources are
+	// marked ready. WhenObserved marks them ready once they exist, while
+	// Always marks them ready even before they are observed.
+	// +kubebuilder:validation:Enum=WhenObserved;Always
+	// +kubebuilder:default=WhenObserved
+	// +optional
+	OptionalResourcePolicy OptionalResourcePolicy `json:"optionalResourcePolicy,omitempty"`
+
+	// Dependencies declare that a composed resource is only considered ready
+	// once the composed resources it depends on are ready. Dependencies are
+	// evaluated after health checks, and must not contain cycles.
+	// +optional
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+
+	// MinReadyDuration is how long a composed resource must continuously pass
+	// its health check before it is reported ready, in time.Duration format.
+	// The time each composed resource was first seen healthy is persisted in
+	// status.autoReady.healthySince of the composite resource.
+	// +optional
+	MinReadyDuration string `json:"minReadyDuration,omitempty"`
+
+	// MinReadyDurationOverrides overrides MinReadyDuration for specific kinds
+	// of composed resource. Keys use the <group>_<version>_<kind> format of
+	// CEL health check customizations.
+	// +optional
+	MinReadyDurationOverrides map[string]string `json:"minReadyDurationOverrides,omitempty"`
+
+	// ProgressDeadline is how long a composed resource may be Progressing
+	// before it's considered Degraded, in time.Duration format. Degraded
+	// composed resources produce a Warning result, and are marked not ready
+	// if UnhealthyPolicy is False. The time each composed resource started
+	// progressing is persisted in status.autoReady.progressingSince of the
+	// composite resource.
+	// +optional
+	ProgressDeadline string `json:"progressDeadline,omitempty"`
+
+	// ProgressDeadlineOverrides overrides ProgressDeadline for specific kinds
+	// of composed resource. Keys use the <group>_<version>_<kind> format of
+	// CEL health check customizations.
+	// +optional
+	ProgressDeadlineOverrides map[string]string `json:"progressDeadlineOverrides,omitempty"`
+
+	// ReadyTTL overrides TTL when every composed resource this Function
+	// evaluated is ready, in time.Duration format. Use a long ReadyTTL to
+	// back off once the composite resource has settled.
+	// +optional
+	ReadyTTL string `json:"readyTTL,omitempty"`
+
+	// ProgressingTTL overrides TTL while any composed resource this Function
+	// evaluated is not ready, in time.Duration format. Use a short
+	// ProgressingTTL to converge quickly during a rollout.
+	// +optional
+	ProgressingTTL string `json:"progressingTTL,omitempty"`
+
+	// ProgressingTTLOverrides overrides ProgressingTTL for specific kinds of
+	// composed resource. Keys use the <group>_<version>_<kind> format of CEL
+	// health check customizations. The response uses the shortest TTL of any
+	// composed resource that is not ready.
+	// +optional
+	ProgressingTTLOverrides map[string]string `json:"progressingTTLOverrides,omitempty"`
+
+	// CompositeReadiness configures this Function to set the readiness of the
+	// composite resource itself, rather than leaving Crossplane to derive it
+	// from the readiness of the composed resources.
+	// +optional
+	CompositeReadiness *CompositeReadiness `json:"compositeReadiness,omitempty"`
+
+	// ReadinessGroups group composed resources that are all reported ready
+	// once a quorum of them pass their health checks, e.g. two of three
+	// regional replicas.
+	// +optional
+	ReadinessGroups []ReadinessGroup `json:"readinessGroups,omitempty"`
+}
+
+// A ReadinessGroup is a group of composed resources that are all reported
+// ready once at least MinReady of them are healthy.
+type ReadinessGroup struct {
+	// Name identifies the group in results.
+	Name string `json:"name"`
+
+	// Resources selects the members of the group from the composed resources
+	// this Function evaluates.
+	// +kubebuilder:validation:MinItems=1
+	Resources []ResourceSelector `json:"resources"`
+
+	// MinReady is the number of members that must be healthy for every
+	// member of the group to be reported ready.
+	// +kubebuilder:validation:Minimum=1
+	MinReady int `json:"minReady"`
+}
+
+// CompositeReadiness determines the readiness of the composite resource.
+type CompositeReadiness struct {
+	// Policy determines how many of the composed resources matched by
+	// Resources must be ready for the composite resource to be ready. All
+	// requires every one of them, Any requires at least one, and MinReady
+	// requires at least MinReady.
+	// +kubebuilder:validation:Enum=All;Any;MinReady
+	// +kubebuilder:default=All
+	// +optional
+	Policy CompositeReadinessPolicy `json:"policy,omitempty"`
+
+	// MinReady is the number of composed resources that must be ready when
+	// Policy is MinReady.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReady *int `json:"minReady,omitempty"`
+
+	// Resources selects the composed resources counted by Policy. All desired
+	// composed resources are counted when Resources is empty.
+	// +optional
+	Resources []ResourceSelector `json:"resources,omitempty"`
+
+	// Expression is a CEL expression over the observed composite resource,
+	// available as object, that must also evaluate to true for the composite
+	// resource to be ready. Requires the CELHealthcheckCustomizations alpha
+	// feature.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+// A CompositeReadinessPolicy determines how many composed resources must be
+// ready for the composite resource to be ready.
+type CompositeReadinessPolicy string
+
+// Supported composite readiness policies.
+const (
+	// CompositeReadinessPolicyAll requires every composed resource to be
+	// ready.
+	CompositeReadinessPolicyAll CompositeReadinessPolicy = "All"
+	// CompositeReadinessPolicyAny requires at least one composed resource to
+	// be ready.
+	CompositeReadinessPolicyAny CompositeReadinessPolicy = "Any"
+	// CompositeReadinessPolicyMinReady requires at least MinReady composed
+	// resources to be ready.
+	CompositeReadinessPolicyMinReady CompositeReadinessPolicy = "MinReady"
+)
+
+// A Dependency declares that a composed resource depends on other composed
+// resources being ready.
+type Dependency struct {
+	// Resource is the composition resource name of the dependent composed
+	// resource.
+	Resource string `json:"resource"`
+
+	// DependsOn are the composition resource names of the composed resources
+	// that must be ready before Resource is considered ready.
+	DependsOn []string `json:"dependsOn"`
+}
+
+// AnnotationKeyOptional marks a desired composed resource as optional when set
+// to "true". Optional resources never block the readiness of the composite
+// resource.
+const AnnotationKeyOptional = "autoready.fn.crossplane.io/optional"
+
+// An OptionalResourcePolicy determines when optional composed resources are
+// marked ready.
+type OptionalResourcePolicy string
+
+// Supported optional resource policies.
+const (
+	// OptionalResourcePolicyWhenObserved marks optional composed resources
+	// ready once they appear in the observed state.
+	OptionalResourcePolicyWhenObserved OptionalResourcePolicy = "WhenObserved"
+	// OptionalResourcePolicyAlways marks optional composed resources ready
+	// even before they appear in the observed state.
+	OptionalResourcePolicyAlways OptionalResourcePolicy = "Always"
+)
+
+// A ResourceSelector selects desired composed resources. A composed resource
+// matches the selector when it matches every field that is set.
+type ResourceSelector struct {
+	// Name is a glob pattern matched against the composition resource name,
+	// e.g. "bucket-*".
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// NameRegex is a regular expression that must match the entire
+	// composition resource name.
+	// +optional
+	NameRegex string `json:"nameRegex,omitempty"`
+
+	// APIVersion is a glob pattern matched against the apiVersion of the
+	// composed resource, e.g. "s3.aws.upbound.io/*".
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind is a glob pattern matched against the kind of the composed resource.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// MatchLabels must all be present on the desired composed resource.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchAnnotations must all be present on the desired composed resource.
+	// +optional
+	MatchAnnotations map[string]string `json:"matchAnnotations,omitempty"`
 }
+
+// A CELHealthCheckRule is a CEL health check for the composed resources it
+// selects.
+type CELHealthCheckRule struct {
+	// Name identifies the rule in results. Defaults to the rule's index.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Group is the API group of the composed resources the rule applies to,
+	// or * for any group. Empty is the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the composed resources the rule applies
+	// to, or * for any version.
+	// +kubebuilder:default="*"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Kind is the kind of the composed resources the rule applies to, or *
+	// for any kind.
+	Kind string `json:"kind"`
+
+	// Resource optionally restricts the rule to composed resources matching
+	// the selector, e.g. by composition resource name or label.
+	// +optional
+	Resource *ResourceSelector `json:"resource,omitempty"`
+
+	// Expression is a CEL expression that returns whether the composed
+	// resource is healthy, or a map with a status and an optional message.
+	Expression string `json:"expression"`
+
+	// MessageExpression is a CEL expression that returns a string to use as
+	// the message of the composed resource's health.
+	// +optional
+	MessageExpression string `json:"messageExpression,omitempty"`
+
+	// Priority orders rules that apply to the same composed resource. Rules
+	// with a higher priority take precedence.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// FailurePolicy determines what happens when the rule fails to evaluate.
+	// Ignore reports a Warning and falls back to the next health check, while
+	// Fail reports the composed resource Degraded.
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	// +kubebuilder:default=Ignore
+	// +optional
+	FailurePolicy CELFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// A CELFailurePolicy determines what happens when a CEL health check rule
+// fails to evaluate.
+type CELFailurePolicy string
+
+// Supported CEL failure policies.
+const (
+	// CELFailurePolicyIgnore reports a Warning and falls back to the next
+	// health check.
+	CELFailurePolicyIgnore CELFailurePolicy = "Ignore"
+	// CELFailurePolicyFail reports the composed resource Degraded.
+	CELFailurePolicyFail CELFailurePolicy = "Fail"
+)
+
+// A StaleStatusPolicy determines how composed resources with a stale status
+// are handled.
+type StaleStatusPolicy string
+
+// Supported stale status policies.
+const (
+	// StaleStatusPolicyProgressing reports composed resources with a stale
+	// status progressing.
+	StaleStatusPolicyProgressing StaleStatusPolicy = "Progressing"
+	// StaleStatusPolicyIgnore evaluates the health checks of composed
+	// resources with a stale status.
+	StaleStatusPolicyIgnore StaleStatusPolicy = "Ignore"
+)
+
+// A CELValidationPolicy determines how invalid CEL health check
+// customizations are reported.
+type CELValidationPolicy string
+
+// Supported CEL validation policies.
+const (
+	// CELValidationPolicyFatal returns a Fatal result.
+	CELValidationPolicyFatal CELValidationPolicy = "Fatal"
+	// CELValidationPolicyWarning returns a Warning result and ignores the
+	// invalid customizations.
+	CELValidationPolicyWarning CELValidationPolicy = "Warning"
+)
+
+// An UnhealthyPolicy determines the readiness of Degraded composed resources.
+type UnhealthyPolicy string
+
+// Supported unhealthy policies.
+const (
+	// UnhealthyPolicyUnspecified leaves the readiness of Degraded composed
+	// resources unspecified.
+	UnhealthyPolicyUnspecified UnhealthyPolicy = "Unspecified"
+	// UnhealthyPolicyFalse marks Degraded composed resources as not ready.
+	UnhealthyPolicyFalse UnhealthyPolicy = "False"
+)
+
+// A Target of the results and conditions emitted by this Function.
+type Target string
+
+// Supported targets.
+const (
+	// TargetComposite targets only the composite resource.
+	TargetComposite Target = "Composite"
+	// TargetCompositeAndClaim targets both the composite resource and its claim.
+	TargetCompositeAndClaim Target = "CompositeAndClaim"
+)
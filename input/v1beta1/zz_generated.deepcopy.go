@@ -8,6 +8,73 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELHealthCheckRule) DeepCopyInto(out *CELHealthCheckRule) {
+	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELHealthCheckRule.
+func (in *CELHealthCheckRule) DeepCopy() *CELHealthCheckRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CELHealthCheckRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeReadiness) DeepCopyInto(out *CompositeReadiness) {
+	*out = *in
+	if in.MinReady != nil {
+		in, out := &in.MinReady, &out.MinReady
+		*out = new(int)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeReadiness.
+func (in *CompositeReadiness) DeepCopy() *CompositeReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dependency) DeepCopyInto(out *Dependency) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dependency.
+func (in *Dependency) DeepCopy() *Dependency {
+	if in == nil {
+		return nil
+	}
+	out := new(Dependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -29,6 +96,115 @@ func (in *Input) DeepCopyInto(out *Input) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CELHealthCheckResourceOverrides != nil {
+		in, out := &in.CELHealthCheckResourceOverrides, &out.CELHealthCheckResourceOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CELHealthCheckRules != nil {
+		in, out := &in.CELHealthCheckRules, &out.CELHealthCheckRules
+		*out = make([]CELHealthCheckRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CELCostLimit != nil {
+		in, out := &in.CELCostLimit, &out.CELCostLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LuaHealthCheckCustomization != nil {
+		in, out := &in.LuaHealthCheckCustomization, &out.LuaHealthCheckCustomization
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LuaHealthCheckCustomizationFrom != nil {
+		in, out := &in.LuaHealthCheckCustomizationFrom, &out.LuaHealthCheckCustomizationFrom
+		*out = new(string)
+		**out = **in
+	}
+	if in.LuaInstructionLimit != nil {
+		in, out := &in.LuaInstructionLimit, &out.LuaInstructionLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthCheckCatalog != nil {
+		in, out := &in.HealthCheckCatalog, &out.HealthCheckCatalog
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaleStatusPolicyOverrides != nil {
+		in, out := &in.StaleStatusPolicyOverrides, &out.StaleStatusPolicyOverrides
+		*out = make(map[string]StaleStatusPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OptionalResources != nil {
+		in, out := &in.OptionalResources, &out.OptionalResources
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]Dependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinReadyDurationOverrides != nil {
+		in, out := &in.MinReadyDurationOverrides, &out.MinReadyDurationOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProgressDeadlineOverrides != nil {
+		in, out := &in.ProgressDeadlineOverrides, &out.ProgressDeadlineOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProgressingTTLOverrides != nil {
+		in, out := &in.ProgressingTTLOverrides, &out.ProgressingTTLOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CompositeReadiness != nil {
+		in, out := &in.CompositeReadiness, &out.CompositeReadiness
+		*out = new(CompositeReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGroups != nil {
+		in, out := &in.ReadinessGroups, &out.ReadinessGroups
+		*out = make([]ReadinessGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Input.
@@ -48,3 +224,54 @@ func (in *Input) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGroup) DeepCopyInto(out *ReadinessGroup) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGroup.
+func (in *ReadinessGroup) DeepCopy() *ReadinessGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchAnnotations != nil {
+		in, out := &in.MatchAnnotations, &out.MatchAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,56 @@
+package lua
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+var errInstructionLimitExceeded = errors.New("instruction limit exceeded")
+
+// An instructionLimit is a context that is done once a Lua state has executed
+// approximately a number of instructions. gopher-lua has no instruction hook,
+// but as of v1.1.1 a Lua state with a context checks whether it's done before
+// it executes each virtual machine instruction, so instructionLimit counts
+// those checks. A call to a Go function, e.g. string.format, counts as one
+// instruction however long it runs. The count depends on gopher-lua's
+// internals, so it's a budget rather than an exact limit; the tests pin it to
+// the version in go.mod. It isn't safe for concurrent use, which is fine
+// because a Lua state runs on a single goroutine.
+type instructionLimit struct {
+	context.Context
+
+	remaining uint64
+	exceeded  chan struct{}
+}
+
+// newInstructionLimit returns a context that is done once the supplied number
+// of instructions has been executed, or when the parent context is done.
+func newInstructionLimit(parent context.Context, limit uint64) *instructionLimit {
+	return &instructionLimit{Context: parent, remaining: limit, exceeded: make(chan struct{})}
+}
+
+// Done counts an instruction, and returns a closed channel once the limit is
+// exceeded.
+func (c *instructionLimit) Done() <-chan struct{} {
+	if c.remaining == 0 {
+		select {
+		case <-c.exceeded:
+		default:
+			close(c.exceeded)
+		}
+		return c.exceeded
+	}
+	c.remaining--
+	return c.Context.Done()
+}
+
+// Err returns errInstructionLimitExceeded once the limit is exceeded.
+func (c *instructionLimit) Err() error {
+	select {
+	case <-c.exceeded:
+		return errInstructionLimitExceeded
+	default:
+		return c.Context.Err()
+	}
+}
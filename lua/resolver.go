@@ -0,0 +1,237 @@
+// Package lua evaluates Argo CD style Lua health checks.
+package lua
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+// ArgoCDKeyPrefix prefixes health check keys in Argo CD's argocd-cm
+// ConfigMap, e.g. resource.customizations.health.cert-manager.io_Certificate.
+const ArgoCDKeyPrefix = "resource.customizations.health."
+
+// Argo CD health statuses that don't have an equivalent health status code.
+const (
+	statusMissing = "Missing"
+	statusUnknown = "Unknown"
+)
+
+// Limits on the memory a Lua state uses for health check scripts' call frames
+// and values on their stack. Argo CD health check scripts need a fraction of
+// them. A script that exceeds either fails with a stack or registry overflow.
+const (
+	// callStackSize is how deeply a script may nest function calls.
+	callStackSize = 128
+
+	// registrySize is how many values a script's stack starts with room for.
+	// It grows by lua.RegistryGrowStep up to registryMaxSize.
+	registrySize    = 1024
+	registryMaxSize = 16 * 1024
+)
+
+// A key is <group>_<kind> or <group>/<kind>. The group is empty, or may be
+// omitted entirely, for the core API group.
+var keyRegex = regexp.MustCompile(`^(?:([a-z0-9.-]*)[_/])?([A-Za-z0-9]+)$`)
+
+// ParseKey parses the supplied health check key. Keys may be prefixed with
+// ArgoCDKeyPrefix.
+func ParseKey(key string) (schema.GroupKind, error) {
+	m := keyRegex.FindStringSubmatch(strings.TrimPrefix(key, ArgoCDKeyPrefix))
+	if m == nil {
+		return schema.GroupKind{}, errors.New("key must be of the form <group>_<kind>, optionally prefixed with " + ArgoCDKeyPrefix)
+	}
+	return schema.GroupKind{Group: m[1], Kind: m[2]}, nil
+}
+
+// A Resolver evaluates Lua health checks keyed by group and kind.
+type Resolver struct {
+	scripts map[schema.GroupKind]*lua.FunctionProto
+
+	// Timeout limits how long each evaluation may run. Zero means no limit.
+	Timeout time.Duration
+
+	// InstructionLimit limits approximately how many virtual machine
+	// instructions each evaluation may execute. Zero means no limit.
+	InstructionLimit uint64
+}
+
+// NewResolver returns a Resolver for the supplied health check scripts, keyed
+// by <group>_<kind>. Scripts in later sources take precedence over scripts for
+// the same group and kind in earlier sources. It returns an error keyed by key
+// for each script with an invalid key or that doesn't compile. Invalid scripts
+// are ignored.
+func NewResolver(sources ...map[string]string) (*Resolver, map[string]error) {
+	r := &Resolver{scripts: make(map[schema.GroupKind]*lua.FunctionProto)}
+	invalid := make(map[string]error)
+	for _, scripts := range sources {
+		for _, key := range slices.Sorted(maps.Keys(scripts)) {
+			gk, err := ParseKey(key)
+			if err != nil {
+				invalid[key] = err
+				continue
+			}
+			p, err := compile(key, scripts[key])
+			if err != nil {
+				invalid[key] = err
+				continue
+			}
+			r.scripts[gk] = p
+		}
+	}
+	return r, invalid
+}
+
+// compile compiles the supplied script.
+func compile(name, script string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(strings.NewReader(script), name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse script")
+	}
+	p, err := lua.Compile(chunk, name)
+	return p, errors.Wrap(err, "cannot compile script")
+}
+
+// HasHealthCheck returns true if there's a health check for the supplied kind.
+func (r *Resolver) HasHealthCheck(gk schema.GroupKind) bool {
+	_, ok := r.scripts[gk]
+	return ok
+}
+
+// HealthStatus evaluates the health check for the kind of the supplied
+// object. The script can refer to the object as obj, and must return a table
+// with a status and an optional message, e.g.
+//
+//	hs = {}
+//	hs.status = "Progressing"
+//	hs.message = "Waiting for rollout"
+//	return hs
+func (r *Resolver) HealthStatus(gk schema.GroupKind, obj map[string]any) (healthchecks.HealthStatus, error) {
+	p, ok := r.scripts[gk]
+	if !ok {
+		return healthchecks.HealthStatus{}, errors.Errorf("no health check for %s", gk)
+	}
+
+	ctx := context.Background()
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	if r.InstructionLimit > 0 {
+		ctx = newInstructionLimit(ctx, r.InstructionLimit)
+	}
+
+	L := newState()
+	defer L.Close()
+	L.SetContext(ctx)
+	L.SetGlobal("obj", toLua(L, obj))
+
+	L.Push(L.NewFunctionFromProto(p))
+	if err := L.PCall(0, 1, nil); err != nil {
+		switch {
+		case errors.Is(ctx.Err(), errInstructionLimitExceeded):
+			return healthchecks.HealthStatus{}, errors.Errorf("health check script exceeded its instruction limit of %d", r.InstructionLimit)
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return healthchecks.HealthStatus{}, errors.Errorf("health check script exceeded its evaluation timeout of %s", r.Timeout)
+		}
+		return healthchecks.HealthStatus{}, errors.Wrap(err, "cannot run health check script")
+	}
+
+	hs, ok := L.Get(-1).(*lua.LTable)
+	if !ok {
+		return healthchecks.HealthStatus{}, errors.Errorf("health check script returned %s, not a table", L.Get(-1).Type())
+	}
+	return healthStatus(hs)
+}
+
+// healthStatus converts the table returned by a health check script to a
+// health status.
+func healthStatus(hs *lua.LTable) (healthchecks.HealthStatus, error) {
+	status := lua.LVAsString(hs.RawGetString("status"))
+	message := lua.LVAsString(hs.RawGetString("message"))
+	switch code := healthchecks.HealthStatusCode(status); code {
+	case healthchecks.HealthStatusHealthy, healthchecks.HealthStatusProgressing, healthchecks.HealthStatusDegraded, healthchecks.HealthStatusSuspended:
+		return healthchecks.HealthStatus{Status: code, Message: message}, nil
+	case statusMissing, statusUnknown:
+		// Neither means the resource is broken, so treat it as
+		// progressing rather than guessing.
+		if message == "" {
+			message = fmt.Sprintf("health check script reported %s", status)
+		}
+		return healthchecks.Progressing(message), nil
+	default:
+		return healthchecks.HealthStatus{}, errors.Errorf("health check script returned unknown status %q, must be one of Healthy, Progressing, Degraded, Suspended, Missing or Unknown", status)
+	}
+}
+
+// newState returns a Lua state with the same libraries Argo CD makes
+// available to health check scripts. Scripts can't access the filesystem or
+// load other code, and their call stack and registry are limited.
+func newState() *lua.LState {
+	L := lua.NewState(lua.Options{
+		SkipOpenLibs:    true,
+		CallStackSize:   callStackSize,
+		RegistrySize:    registrySize,
+		RegistryMaxSize: registryMaxSize,
+	})
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, fn := range []string{"dofile", "loadfile", "load", "loadstring", "require", "module"} {
+		L.SetGlobal(fn, lua.LNil)
+	}
+	return L
+}
+
+// toLua converts a value decoded from JSON to a Lua value. Arrays become
+// tables indexed from 1, like Argo CD's.
+func toLua(L *lua.LState, v any) lua.LValue {
+	switch v := v.(type) {
+	case map[string]any:
+		t := L.CreateTable(0, len(v))
+		for k, e := range v {
+			t.RawSetString(k, toLua(L, e))
+		}
+		return t
+	case []any:
+		t := L.CreateTable(len(v), 0)
+		for _, e := range v {
+			t.Append(toLua(L, e))
+		}
+		return t
+	case string:
+		return lua.LString(v)
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case int64:
+		return lua.LNumber(v)
+	case int:
+		return lua.LNumber(v)
+	default:
+		return lua.LNil
+	}
+}
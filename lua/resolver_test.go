@@ -0,0 +1,269 @@
+package lua
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+// certificateHealth is Argo CD's health check for cert-manager Certificates.
+const certificateHealth = `
+hs = {}
+if obj.status ~= nil then
+  if obj.status.conditions ~= nil then
+    for i, condition in ipairs(obj.status.conditions) do
+      if condition.type == "Issuing" and condition.status == "True" then
+        hs.status = "Progressing"
+        hs.message = condition.message
+        return hs
+      end
+      if condition.type == "Ready" and condition.status == "False" then
+        hs.status = "Degraded"
+        hs.message = condition.message
+        return hs
+      end
+      if condition.type == "Ready" and condition.status == "True" then
+        hs.status = "Healthy"
+        hs.message = condition.message
+        return hs
+      end
+    end
+  end
+end
+
+hs.status = "Progressing"
+hs.message = "Waiting for certificate"
+return hs
+`
+
+func TestParseKey(t *testing.T) {
+	type want struct {
+		gk  schema.GroupKind
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		key    string
+		want   want
+	}{
+		"GroupKind": {
+			reason: "A <group>_<kind> key should be parsed",
+			key:    "cert-manager.io_Certificate",
+			want:   want{gk: schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"}},
+		},
+		"ArgoCDPrefix": {
+			reason: "A key from argocd-cm should be parsed",
+			key:    "resource.customizations.health.cert-manager.io_Certificate",
+			want:   want{gk: schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"}},
+		},
+		"Slash": {
+			reason: "A <group>/<kind> key, like the path of an Argo CD resource customization, should be parsed",
+			key:    "argoproj.io/Rollout",
+			want:   want{gk: schema.GroupKind{Group: "argoproj.io", Kind: "Rollout"}},
+		},
+		"CoreGroup": {
+			reason: "A key without a group should be parsed as the core API group",
+			key:    "PersistentVolumeClaim",
+			want:   want{gk: schema.GroupKind{Kind: "PersistentVolumeClaim"}},
+		},
+		"Invalid": {
+			reason: "A key with a version should return an error",
+			key:    "apps_v1_Deployment",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gk, err := ParseKey(tc.key)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nParseKey(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.gk, gk); diff != "" {
+				t.Errorf("%s\nParseKey(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewResolver(t *testing.T) {
+	r, invalid := NewResolver(
+		map[string]string{
+			"resource.customizations.health.cert-manager.io_Certificate": `return {status = "Degraded"}`,
+			"apps_v1_Deployment": `return {status = "Healthy"}`,
+			"example.org_Broken": `return {`,
+		},
+		map[string]string{
+			"cert-manager.io_Certificate": `return {status = "Healthy"}`,
+		},
+	)
+
+	got := make(map[string]bool, len(invalid))
+	for key := range invalid {
+		got[key] = true
+	}
+	if diff := cmp.Diff(map[string]bool{"apps_v1_Deployment": true, "example.org_Broken": true}, got); diff != "" {
+		t.Errorf("NewResolver(...): -want invalid, +got invalid:\n%s", diff)
+	}
+
+	h, err := r.HealthStatus(schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"}, map[string]any{})
+	if err != nil {
+		t.Fatalf("HealthStatus(...): %v", err)
+	}
+	if diff := cmp.Diff(healthchecks.Healthy(""), h); diff != "" {
+		t.Errorf("HealthStatus(...): later sources should take precedence: -want, +got:\n%s", diff)
+	}
+}
+
+func TestHealthStatus(t *testing.T) {
+	certificate := schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"}
+
+	type want struct {
+		health healthchecks.HealthStatus
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason           string
+		script           string
+		timeout          time.Duration
+		instructionLimit uint64
+		obj              map[string]any
+		want             want
+	}{
+		"ArgoCDHealthy": {
+			reason: "An Argo CD health check should report a ready certificate healthy",
+			script: certificateHealth,
+			obj: map[string]any{"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True", "message": "Certificate is up to date"},
+			}}},
+			want: want{health: healthchecks.Healthy("Certificate is up to date")},
+		},
+		"ArgoCDDegraded": {
+			reason: "An Argo CD health check should report a failed certificate degraded",
+			script: certificateHealth,
+			obj: map[string]any{"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "message": "Issuer not found"},
+			}}},
+			want: want{health: healthchecks.Degraded("Issuer not found")},
+		},
+		"ArgoCDNoStatus": {
+			reason: "An Argo CD health check should report a certificate without a status progressing",
+			script: certificateHealth,
+			obj:    map[string]any{},
+			want:   want{health: healthchecks.Progressing("Waiting for certificate")},
+		},
+		"Numbers": {
+			reason: "Numbers should be converted to Lua numbers",
+			script: `if obj.spec.replicas == 3 then return {status = "Healthy"} end return {status = "Progressing"}`,
+			obj:    map[string]any{"spec": map[string]any{"replicas": int64(3)}},
+			want:   want{health: healthchecks.Healthy("")},
+		},
+		"Missing": {
+			reason: "Argo CD's Missing status should be reported as progressing",
+			script: `return {status = "Missing"}`,
+			obj:    map[string]any{},
+			want:   want{health: healthchecks.Progressing("health check script reported Missing")},
+		},
+		"UnknownStatus": {
+			reason: "An unknown status should return an error",
+			script: `return {status = "Great"}`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"NotATable": {
+			reason: "A script that doesn't return a table should return an error",
+			script: `return "Healthy"`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"RuntimeError": {
+			reason: "A script that raises an error should return an error",
+			script: `return {status = obj.status.phase}`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"NoFilesystem": {
+			reason: "Scripts should not be able to load files",
+			script: `dofile("/etc/passwd") return {status = "Healthy"}`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"Timeout": {
+			reason:  "A script that exceeds its timeout should return an error",
+			script:  `while true do end`,
+			timeout: 10 * time.Millisecond,
+			obj:     map[string]any{},
+			want:    want{err: true},
+		},
+		"InstructionLimit": {
+			reason:           "A script that exceeds its instruction limit should return an error",
+			script:           `while true do end`,
+			instructionLimit: 1000,
+			obj:              map[string]any{},
+			want:             want{err: true},
+		},
+		"WithinInstructionLimit": {
+			reason:           "A script that finishes within its instruction limit should report its health",
+			script:           `local n = 0 for i = 1, 10 do n = n + i end return {status = "Healthy", message = tostring(n)}`,
+			instructionLimit: 1000,
+			obj:              map[string]any{},
+			want:             want{health: healthchecks.Healthy("55")},
+		},
+		"ExactInstructionLimit": {
+			reason:           "A script should be able to execute exactly its instruction limit. The count is specific to the version of gopher-lua in go.mod; a loop of 100 iterations executes 213 instructions in v1.1.1",
+			script:           `local n = 0 for i = 1, 100 do n = n + i end return {status = "Healthy", message = tostring(n)}`,
+			instructionLimit: 213,
+			obj:              map[string]any{},
+			want:             want{health: healthchecks.Healthy("5050")},
+		},
+		"OneInstructionOverLimit": {
+			reason:           "A script that executes one instruction more than its instruction limit should return an error",
+			script:           `local n = 0 for i = 1, 100 do n = n + i end return {status = "Healthy", message = tostring(n)}`,
+			instructionLimit: 212,
+			obj:              map[string]any{},
+			want:             want{err: true},
+		},
+		"CallStackOverflow": {
+			reason: "A script that nests function calls deeper than the call stack size should return an error",
+			script: `local function f(n) if n == 0 then return {status = "Healthy"} end local hs = f(n - 1) return hs end return f(1000)`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"RegistryOverflow": {
+			reason: "A script that pushes more values onto its stack than the registry can hold should return an error",
+			script: `local t = {} for i = 1, 100000 do t[i] = i end return {status = "Healthy", message = tostring(select("#", unpack(t)))}`,
+			obj:    map[string]any{},
+			want:   want{err: true},
+		},
+		"WithinRegistry": {
+			reason: "A script that pushes fewer values onto its stack than the registry can hold should report its health",
+			script: `local t = {} for i = 1, 1000 do t[i] = i end return {status = "Healthy", message = tostring(select("#", unpack(t)))}`,
+			obj:    map[string]any{},
+			want:   want{health: healthchecks.Healthy("1000")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, invalid := NewResolver(map[string]string{"cert-manager.io_Certificate": tc.script})
+			if len(invalid) > 0 {
+				t.Fatalf("%s\nNewResolver(...): %v", tc.reason, invalid)
+			}
+			r.Timeout = tc.timeout
+			r.InstructionLimit = tc.instructionLimit
+			h, err := r.HealthStatus(certificate, tc.obj)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nHealthStatus(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.health, h); diff != "" {
+				t.Errorf("%s\nHealthStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/crossplane/function-auto-ready/cel"
 	"github.com/crossplane/function-auto-ready/features"
 	"github.com/crossplane/function-sdk-go"
 	"github.com/crossplane/function-sdk-go/response"
@@ -21,6 +23,14 @@ type CLI struct {
 	Insecure           bool           `help:"Run without mTLS credentials. If you supply this flag --tls-server-certs-dir will be ignored."`
 	MaxRecvMessageSize int            `help:"Maximum size of received messages in MB." default:"4"`
 	TTL                *time.Duration `help:"Time to live for function response."`
+	MetricsAddress     string         `help:"Address at which to serve Prometheus metrics. Set to an empty string to disable metrics." default:":8080"`
+
+	CELProgramCacheSize  int           `help:"Maximum number of compiled CEL programs to cache." default:"1024"`
+	CELCostLimit         uint64        `help:"Maximum runtime cost of evaluating a CEL expression. The function input may only lower it. Set to 0 for no limit." default:"1000000"`
+	CELEvaluationTimeout time.Duration `help:"Maximum time spent evaluating a CEL expression. The function input may only lower it. Set to 0 for no limit." default:"1s"`
+
+	LuaInstructionLimit  uint64        `help:"Approximate maximum number of instructions executed evaluating a Lua health check script. The function input may only lower it. Set to 0 for no limit." default:"1000000"`
+	LuaEvaluationTimeout time.Duration `help:"Maximum time spent evaluating a Lua health check script. The function input may only lower it. Set to 0 for no limit." default:"1s"`
 
 	FeatureGates string `default:""     help:"Feature gates to enable/disable (e.g. CELHealthcheckCustomizations=true)."`
 }
@@ -42,8 +52,25 @@ func (c *CLI) Run() error {
 		}
 	}
 
-	return function.Serve(&Function{log: log, ttl: ttl},
+	programs := cel.NewProgramCache(c.CELProgramCacheSize)
+	if err := prometheus.DefaultRegisterer.Register(programs); err != nil {
+		return err
+	}
+
+	f := &Function{
+		log:          log,
+		ttl:          ttl,
+		programs:     programs,
+		celCostLimit: c.CELCostLimit,
+		celTimeout:   c.CELEvaluationTimeout,
+
+		luaInstructionLimit: c.LuaInstructionLimit,
+		luaTimeout:          c.LuaEvaluationTimeout,
+	}
+
+	return function.Serve(f,
 		function.Listen(c.Network, c.Address),
+		function.WithMetricsServer(c.MetricsAddress),
 		function.MTLSCertificates(c.TLSCertsDir),
 		function.Insecure(c.Insecure),
 		function.MaxRecvMessageSize(c.MaxRecvMessageSize*1024*1024))
@@ -0,0 +1,27 @@
+// Package nested reads fields of unstructured Kubernetes objects.
+package nested
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Int64 returns the integer at the supplied path of an object, and whether
+// there is one. Objects decoded from JSON, including those sent to a Function
+// in a RunFunctionRequest, represent numbers as float64, so it accepts those
+// as well as int64 and int.
+func Int64(obj map[string]any, path ...string) (int64, bool) {
+	v, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+	if err != nil || !found {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
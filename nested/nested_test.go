@@ -0,0 +1,60 @@
+package nested
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInt64(t *testing.T) {
+	type want struct {
+		n     int64
+		found bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    map[string]any
+		want   want
+	}{
+		"Int64": {
+			reason: "An int64 should be returned as is",
+			obj:    map[string]any{"spec": map[string]any{"replicas": int64(3)}},
+			want:   want{n: 3, found: true},
+		},
+		"Int": {
+			reason: "An int should be converted",
+			obj:    map[string]any{"spec": map[string]any{"replicas": 3}},
+			want:   want{n: 3, found: true},
+		},
+		"Float64": {
+			reason: "A float64, as decoded from JSON, should be converted",
+			obj:    map[string]any{"spec": map[string]any{"replicas": float64(3)}},
+			want:   want{n: 3, found: true},
+		},
+		"Missing": {
+			reason: "A missing field should not be found",
+			obj:    map[string]any{"spec": map[string]any{}},
+			want:   want{},
+		},
+		"NotANumber": {
+			reason: "A field that isn't a number should not be found",
+			obj:    map[string]any{"spec": map[string]any{"replicas": "3"}},
+			want:   want{},
+		},
+		"NotAnObject": {
+			reason: "A path through a field that isn't an object should not be found",
+			obj:    map[string]any{"spec": "replicas"},
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, found := Int64(tc.obj, "spec", "replicas")
+			if diff := cmp.Diff(tc.want, want{n: n, found: found}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("%s\nInt64(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// optionalResources returns the selected composed resources that are optional,
+// either because they match one of the input's optional resource selectors or
+// because they're annotated as optional.
+func optionalResources(in *v1beta1.Input, selected map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) (map[resource.Name]bool, error) {
+	selectors, err := compileSelectors(in.OptionalResources)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compile optional resource selectors")
+	}
+
+	optional := make(map[resource.Name]bool)
+	for name, dr := range selected {
+		if dr.Resource.GetAnnotations()[v1beta1.AnnotationKeyOptional] == "true" || matchesAny(selectors, name, dr, observed[name]) {
+			optional[name] = true
+		}
+	}
+	return optional, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	fnv1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/response"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// ConditionTypeResourcesHealthy is the type of the composite resource
+// condition summarising the health of the composed resources this Function
+// evaluated.
+const ConditionTypeResourcesHealthy = "ResourcesHealthy"
+
+// Reasons of the ResourcesHealthy condition.
+const (
+	ReasonAllHealthy  = "AllHealthy"
+	ReasonProgressing = "Progressing"
+	ReasonDegraded    = "Degraded"
+)
+
+// readyConditionHealth derives the health of a composed resource whose Ready
+// status condition is not True.
+func readyConditionHealth(c xpv2.Condition) healthchecks.HealthStatus {
+	if c.Status == corev1.ConditionUnknown && c.Reason == "" {
+		return healthchecks.Progressing("waiting for Ready condition")
+	}
+	msg := fmt.Sprintf("Ready condition is %s", c.Status)
+	if c.Reason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, c.Reason)
+	}
+	if c.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, c.Message)
+	}
+	return healthchecks.Progressing(msg)
+}
+
+// resourceLabel identifies a composed resource in results and conditions by
+// its lowercase kind and composition resource name, e.g. "deployment web".
+func resourceLabel(kind string, name resource.Name) string {
+	if kind != "" {
+		return fmt.Sprintf("%s %s", strings.ToLower(kind), name)
+	}
+	return string(name)
+}
+
+// healthMessage formats the health of a composed resource for results and
+// conditions, e.g. "deployment web: 2/3 replicas available".
+func healthMessage(kind string, name resource.Name, h healthchecks.HealthStatus) string {
+	msg := h.Message
+	if msg == "" {
+		msg = strings.ToLower(string(h.Status))
+	}
+	return fmt.Sprintf("%s: %s", resourceLabel(kind, name), msg)
+}
+
+// reportHealth emits a result for every composed resource that is not
+// healthy, and sets a condition on the composite resource summarising them.
+// Degraded and optional resources produce Warning results, all others Normal
+// results. Optional resources are marked ready regardless of their health, so
+// a Warning is the only signal that they are not healthy.
+func reportHealth(rsp *fnv1.RunFunctionResponse, target v1beta1.Target, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed, health map[resource.Name]healthchecks.HealthStatus, optional map[resource.Name]bool) {
+	reason := ReasonAllHealthy
+	unhealthy := make([]string, 0)
+
+	for _, name := range slices.Sorted(maps.Keys(health)) {
+		h := health[name]
+		if h.IsHealthy() {
+			continue
+		}
+
+		msg := healthMessage(composedKind(desired[name], observed[name]), name, h)
+		if optional[name] {
+			msg += " (optional)"
+		}
+		unhealthy = append(unhealthy, msg)
+
+		switch {
+		case h.Status == healthchecks.HealthStatusDegraded:
+			reason = ReasonDegraded
+		case reason == ReasonAllHealthy:
+			reason = ReasonProgressing
+		}
+
+		var r *response.ResultOption
+		if h.Status == healthchecks.HealthStatusDegraded || optional[name] {
+			r = response.Warning(rsp, errors.New(msg))
+		} else {
+			r = response.Normal(rsp, msg)
+		}
+		r.WithReason(string(h.Status))
+		if target == v1beta1.TargetCompositeAndClaim {
+			r.TargetCompositeAndClaim()
+		}
+	}
+
+	var c *response.ConditionOption
+	if len(unhealthy) == 0 {
+		c = response.ConditionTrue(rsp, ConditionTypeResourcesHealthy, reason)
+	} else {
+		c = response.ConditionFalse(rsp, ConditionTypeResourcesHealthy, reason).
+			WithMessage(fmt.Sprintf("Composed resources not healthy: %s", strings.Join(unhealthy, "; ")))
+	}
+	if target == v1beta1.TargetCompositeAndClaim {
+		c.TargetCompositeAndClaim()
+	}
+}
+
+// composedKind returns the kind of a composed resource, preferring the
+// desired resource and falling back to the observed resource.
+func composedKind(dr *resource.DesiredComposed, or resource.ObservedComposed) string {
+	if kind := dr.Resource.GetKind(); kind != "" {
+		return kind
+	}
+	if or.Resource != nil {
+		return or.Resource.GetKind()
+	}
+	return ""
+}
+
+// composedGVKs returns the GVK of every composed resource with a recorded
+// health, preferring the observed resource and falling back to the desired
+// resource for composed resources that don't exist yet.
+func composedGVKs(health map[resource.Name]healthchecks.HealthStatus, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) map[resource.Name]schema.GroupVersionKind {
+	gvks := make(map[resource.Name]schema.GroupVersionKind, len(health))
+	for name := range health {
+		if or, ok := observed[name]; ok {
+			gvks[name] = or.Resource.GroupVersionKind()
+			continue
+		}
+		gvks[name] = desired[name].Resource.GroupVersionKind()
+	}
+	return gvks
+}
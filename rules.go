@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/cel"
+	"github.com/crossplane/function-auto-ready/gvkkey"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// celRules returns the CEL health check rules configured by the input. The
+// supplied customizations keyed by <group>_<version>_<kind> are converted to
+// rules with priority 0, and the input's customizations keyed by composition
+// resource name to overrides. It returns an error keyed by rule name for each
+// rule that can't be converted.
+func celRules(in *v1beta1.Input, customizations map[string]string, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) ([]cel.Rule, map[string]error) {
+	rules := make([]cel.Rule, 0, len(in.CELHealthCheckRules)+len(in.CELHealthCheckResourceOverrides)+len(customizations))
+	invalid := make(map[string]error)
+
+	for i, r := range in.CELHealthCheckRules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("celHealthCheckRules[%d]", i)
+		}
+		rule, err := celRule(name, r, desired, observed)
+		if err != nil {
+			invalid[name] = err
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(in.CELHealthCheckResourceOverrides)) {
+		rule, err := celRule("resource "+name, v1beta1.CELHealthCheckRule{
+			Group:      gvkkey.Wildcard,
+			Kind:       gvkkey.Wildcard,
+			Resource:   &v1beta1.ResourceSelector{NameRegex: regexp.QuoteMeta(name)},
+			Expression: in.CELHealthCheckResourceOverrides[name],
+		}, desired, observed)
+		if err != nil {
+			invalid["resource "+name] = err
+			continue
+		}
+		rule.Override = true
+		rules = append(rules, rule)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(customizations)) {
+		rule, err := cel.RuleFromKey(key, customizations[key])
+		if err != nil {
+			invalid[key] = err
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, invalid
+}
+
+// celRule converts a CEL health check rule from the input.
+func celRule(name string, r v1beta1.CELHealthCheckRule, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) (cel.Rule, error) {
+	if r.Kind == "" {
+		return cel.Rule{}, errors.New("kind is required")
+	}
+	if r.Expression == "" {
+		return cel.Rule{}, errors.New("expression is required")
+	}
+
+	rule := cel.Rule{
+		Name:              name,
+		Group:             r.Group,
+		Version:           r.Version,
+		Kind:              r.Kind,
+		Expression:        r.Expression,
+		MessageExpression: r.MessageExpression,
+		Priority:          r.Priority,
+		FailurePolicy:     cel.FailurePolicyIgnore,
+	}
+	if rule.Version == "" {
+		rule.Version = gvkkey.Wildcard
+	}
+	if r.FailurePolicy == v1beta1.CELFailurePolicyFail {
+		rule.FailurePolicy = cel.FailurePolicyFail
+	}
+
+	if r.Resource != nil {
+		selectors, err := compileSelectors([]v1beta1.ResourceSelector{*r.Resource})
+		if err != nil {
+			return cel.Rule{}, errors.Wrap(err, "cannot compile resource selector")
+		}
+		rule.Match = func(name string) bool {
+			n := resource.Name(name)
+			dr, ok := desired[n]
+			if !ok {
+				return false
+			}
+			return selectors[0].Matches(n, dr, observed[n])
+		}
+	}
+
+	return rule, nil
+}
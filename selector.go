@@ -0,0 +1,126 @@
+package main
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// A resourceSelector is a v1beta1.ResourceSelector with its name regular
+// expression compiled.
+type resourceSelector struct {
+	v1beta1.ResourceSelector
+
+	nameRegex *regexp.Regexp
+}
+
+// compileSelectors validates the supplied selectors and compiles their
+// regular expressions.
+func compileSelectors(in []v1beta1.ResourceSelector) ([]resourceSelector, error) {
+	out := make([]resourceSelector, 0, len(in))
+	for i, s := range in {
+		for _, p := range []string{s.Name, s.APIVersion, s.Kind} {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid glob pattern %q in selector %d", p, i)
+			}
+		}
+		rs := resourceSelector{ResourceSelector: s}
+		if s.NameRegex != "" {
+			re, err := regexp.Compile("^(?:" + s.NameRegex + ")$")
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid name regex in selector %d", i)
+			}
+			rs.nameRegex = re
+		}
+		out = append(out, rs)
+	}
+	return out, nil
+}
+
+// Matches returns true if the supplied composed resource matches the
+// selector. The apiVersion and kind of the desired resource are used, falling
+// back to the observed resource when the desired resource doesn't set them.
+func (s resourceSelector) Matches(name resource.Name, dr *resource.DesiredComposed, or resource.ObservedComposed) bool {
+	if !globMatch(s.Name, string(name)) {
+		return false
+	}
+	if s.nameRegex != nil && !s.nameRegex.MatchString(string(name)) {
+		return false
+	}
+
+	apiVersion, kind := dr.Resource.GetAPIVersion(), dr.Resource.GetKind()
+	if or.Resource != nil {
+		if apiVersion == "" {
+			apiVersion = or.Resource.GetAPIVersion()
+		}
+		if kind == "" {
+			kind = or.Resource.GetKind()
+		}
+	}
+	if !globMatch(s.APIVersion, apiVersion) || !globMatch(s.Kind, kind) {
+		return false
+	}
+
+	return containsAll(dr.Resource.GetLabels(), s.MatchLabels) &&
+		containsAll(dr.Resource.GetAnnotations(), s.MatchAnnotations)
+}
+
+// matchesAny returns true if the composed resource matches any of the
+// supplied selectors.
+func matchesAny(selectors []resourceSelector, name resource.Name, dr *resource.DesiredComposed, or resource.ObservedComposed) bool {
+	for _, s := range selectors {
+		if s.Matches(name, dr, or) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectResources returns the desired composed resources this Function should
+// evaluate, honouring the input's include and exclude selectors.
+func selectResources(in *v1beta1.Input, desired map[resource.Name]*resource.DesiredComposed, observed map[resource.Name]resource.ObservedComposed) (map[resource.Name]*resource.DesiredComposed, error) {
+	include, err := compileSelectors(in.Include)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compile include selectors")
+	}
+	exclude, err := compileSelectors(in.Exclude)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compile exclude selectors")
+	}
+
+	selected := make(map[resource.Name]*resource.DesiredComposed, len(desired))
+	for name, dr := range desired {
+		or := observed[name]
+		if len(include) > 0 && !matchesAny(include, name, dr, or) {
+			continue
+		}
+		if matchesAny(exclude, name, dr, or) {
+			continue
+		}
+		selected[name] = dr
+	}
+	return selected, nil
+}
+
+// globMatch returns true if the pattern is empty or matches the value.
+func globMatch(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// containsAll returns true if have contains every key and value in want.
+func containsAll(have, want map[string]string) bool {
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
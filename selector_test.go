@@ -0,0 +1,107 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+func TestSelectResources(t *testing.T) {
+	desiredComposed := func(apiVersion, kind string, labels, annotations map[string]string) *resource.DesiredComposed {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetLabels(labels)
+		u.SetAnnotations(annotations)
+		return &resource.DesiredComposed{Resource: &composed.Unstructured{Unstructured: *u}}
+	}
+
+	desired := map[resource.Name]*resource.DesiredComposed{
+		"bucket-a":   desiredComposed("s3.aws.upbound.io/v1beta1", "Bucket", map[string]string{"tier": "storage"}, nil),
+		"bucket-b":   desiredComposed("s3.aws.upbound.io/v1beta2", "Bucket", nil, map[string]string{"managed-by": "other"}),
+		"deployment": desiredComposed("apps/v1", "Deployment", map[string]string{"tier": "web"}, nil),
+		"empty":      {Resource: composed.New()},
+	}
+	observed := map[resource.Name]resource.ObservedComposed{
+		"empty": {Resource: desiredComposed("v1", "ConfigMap", nil, nil).Resource},
+	}
+
+	type want struct {
+		names []resource.Name
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		input  *v1beta1.Input
+		want   want
+	}{
+		"NoSelectors": {
+			reason: "All composed resources should be selected when there are no selectors",
+			input:  &v1beta1.Input{},
+			want:   want{names: []resource.Name{"bucket-a", "bucket-b", "deployment", "empty"}},
+		},
+		"IncludeByNameGlob": {
+			reason: "Only composed resources whose name matches an include glob should be selected",
+			input:  &v1beta1.Input{Include: []v1beta1.ResourceSelector{{Name: "bucket-*"}}},
+			want:   want{names: []resource.Name{"bucket-a", "bucket-b"}},
+		},
+		"IncludeByNameRegex": {
+			reason: "Name regular expressions should match the entire composition resource name",
+			input:  &v1beta1.Input{Include: []v1beta1.ResourceSelector{{NameRegex: "bucket-[a-z]"}, {NameRegex: "deploy"}}},
+			want:   want{names: []resource.Name{"bucket-a", "bucket-b"}},
+		},
+		"IncludeByGVK": {
+			reason: "Composed resources should be selected by apiVersion and kind, falling back to the observed resource",
+			input: &v1beta1.Input{Include: []v1beta1.ResourceSelector{
+				{APIVersion: "s3.aws.upbound.io/*", Kind: "Bucket"},
+				{Kind: "ConfigMap"},
+			}},
+			want: want{names: []resource.Name{"bucket-a", "bucket-b", "empty"}},
+		},
+		"ExcludeByLabelAndAnnotation": {
+			reason: "Composed resources matching an exclude selector should not be selected",
+			input: &v1beta1.Input{Exclude: []v1beta1.ResourceSelector{
+				{MatchLabels: map[string]string{"tier": "web"}},
+				{MatchAnnotations: map[string]string{"managed-by": "other"}},
+			}},
+			want: want{names: []resource.Name{"bucket-a", "empty"}},
+		},
+		"ExcludeWinsOverInclude": {
+			reason: "Exclude selectors should take precedence over include selectors",
+			input: &v1beta1.Input{
+				Include: []v1beta1.ResourceSelector{{Kind: "Bucket"}},
+				Exclude: []v1beta1.ResourceSelector{{Name: "bucket-b"}},
+			},
+			want: want{names: []resource.Name{"bucket-a"}},
+		},
+		"InvalidRegex": {
+			reason: "An invalid name regular expression should return an error",
+			input:  &v1beta1.Input{Include: []v1beta1.ResourceSelector{{NameRegex: "("}}},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			selected, err := selectResources(tc.input, desired, observed)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("%s\nselectResources(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if tc.want.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want.names, slices.Sorted(maps.Keys(selected))); diff != "" {
+				t.Errorf("%s\nselectResources(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+// The Function is stateless, so it round-trips when composed resources became
+// healthy or started progressing through the status of the composite
+// resource, which is the only part of it a Function can change. Each is
+// recorded as an object under status.autoReady mapping composition resource
+// names to RFC 3339 timestamps.
+const (
+	// StatusFieldHealthySince records when each composed resource was first
+	// seen healthy.
+	StatusFieldHealthySince = "healthySince"
+
+	// StatusFieldProgressingSince records when each composed resource started
+	// progressing.
+	StatusFieldProgressingSince = "progressingSince"
+)
+
+// getTimestamps reads the per composed resource timestamps persisted in the
+// supplied field of the composite resource's status.autoReady. A missing or
+// malformed field is treated as empty; it's overwritten when timestamps are
+// next persisted.
+func getTimestamps(xr *resource.Composite, field string) map[resource.Name]time.Time {
+	ts := make(map[resource.Name]time.Time)
+
+	raw, _, err := unstructured.NestedStringMap(xr.Resource.Object, "status", "autoReady", field)
+	if err != nil {
+		return ts
+	}
+	for name, v := range raw {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			continue
+		}
+		ts[resource.Name(name)] = t
+	}
+	return ts
+}
+
+// setTimestamps persists the supplied per composed resource timestamps in the
+// supplied field of the composite resource's status.autoReady, removing it
+// when there are none.
+func setTimestamps(xr *resource.Composite, field string, ts map[resource.Name]time.Time) error {
+	if len(ts) == 0 {
+		unstructured.RemoveNestedField(xr.Resource.Object, "status", "autoReady", field)
+		return nil
+	}
+
+	raw := make(map[string]string, len(ts))
+	for name, t := range ts {
+		raw[string(name)] = t.UTC().Format(time.RFC3339)
+	}
+	return errors.Wrapf(unstructured.SetNestedStringMap(xr.Resource.Object, raw, "status", "autoReady", field), "cannot set status.autoReady.%s", field)
+}
+
+// applyMinReadyDuration reports healthy composed resources that haven't been
+// healthy for their minimum ready duration as Progressing. It returns when each
+// healthy composed resource was first seen healthy, and the shortest time until
+// a composed resource that is waiting reaches its minimum ready duration.
+func applyMinReadyDuration(now time.Time, minReady gvkDurations, health map[resource.Name]healthchecks.HealthStatus, gvks map[resource.Name]schema.GroupVersionKind, healthySince map[resource.Name]time.Time) (map[resource.Name]time.Time, time.Duration) {
+	since := make(map[resource.Name]time.Time)
+	var wait time.Duration
+
+	for name, h := range health {
+		if !h.IsHealthy() {
+			continue
+		}
+
+		first, ok := healthySince[name]
+		if !ok || first.After(now) {
+			first = now
+		}
+		since[name] = first
+
+		d := minReady.For(gvks[name])
+		healthyFor := now.Sub(first)
+		if healthyFor >= d {
+			continue
+		}
+
+		wait = shortest(wait, d-healthyFor)
+		health[name] = healthchecks.Progressing(fmt.Sprintf("healthy for %s, waiting until healthy for %s", healthyFor.Truncate(time.Second), d))
+	}
+
+	return since, wait
+}
+
+// applyProgressDeadline reports composed resources that have been Progressing
+// for longer than their progress deadline as Degraded. It returns when each
+// Progressing composed resource started progressing, and the shortest time
+// until a Progressing composed resource reaches its progress deadline.
+//
+// A composed resource that wasn't progressing before starts progressing now.
+// Its creation timestamp and the last transition time of its status
+// conditions may be long before it started progressing, e.g. when an existing
+// Deployment starts rolling out, so they're ignored. A composed resource
+// created since the Function last observed the composite resource already
+// started progressing then, because the Function reports composed resources
+// that don't exist yet as Progressing.
+func applyProgressDeadline(now time.Time, deadlines gvkDurations, health map[resource.Name]healthchecks.HealthStatus, gvks map[resource.Name]schema.GroupVersionKind, progressingSince map[resource.Name]time.Time) (map[resource.Name]time.Time, time.Duration) {
+	since := make(map[resource.Name]time.Time)
+	var wait time.Duration
+
+	for name, h := range health {
+		if h.Status != healthchecks.HealthStatusProgressing {
+			continue
+		}
+
+		first, ok := progressingSince[name]
+		if !ok || first.After(now) {
+			first = now
+		}
+		since[name] = first
+
+		d := deadlines.For(gvks[name])
+		if d == 0 {
+			continue
+		}
+
+		progressingFor := now.Sub(first)
+		if progressingFor < d {
+			wait = shortest(wait, d-progressingFor)
+			continue
+		}
+
+		msg := fmt.Sprintf("progressing for %s, exceeded progress deadline of %s", progressingFor.Truncate(time.Second), d)
+		if h.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, h.Message)
+		}
+		health[name] = healthchecks.Degraded(msg)
+	}
+
+	return since, wait
+}
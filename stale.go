@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+
+	"github.com/crossplane/function-auto-ready/gvkkey"
+	"github.com/crossplane/function-auto-ready/input/v1beta1"
+)
+
+// staleStatusPolicies is a stale status policy that can be overridden for
+// specific kinds of composed resource. Overrides are keyed by
+// <group>_<version>_<kind>, where the version and kind may be wildcards.
+type staleStatusPolicies struct {
+	fallback  v1beta1.StaleStatusPolicy
+	overrides map[string]v1beta1.StaleStatusPolicy
+}
+
+// parseStaleStatusPolicies parses a fallback stale status policy and its
+// per-GVK overrides. An empty fallback is parsed as Progressing.
+func parseStaleStatusPolicies(fallback v1beta1.StaleStatusPolicy, overrides map[string]v1beta1.StaleStatusPolicy) (staleStatusPolicies, error) {
+	p := staleStatusPolicies{fallback: fallback, overrides: overrides}
+	if p.fallback == "" {
+		p.fallback = v1beta1.StaleStatusPolicyProgressing
+	}
+	for k := range overrides {
+		if !gvkkey.Valid(k) {
+			return p, errors.Errorf("invalid key %q: must be of the form <group>_<version>_<kind>, where the version and kind may be *", k)
+		}
+	}
+	return p, nil
+}
+
+// For returns the stale status policy for the supplied kind of composed
+// resource.
+func (p staleStatusPolicies) For(gvk schema.GroupVersionKind) v1beta1.StaleStatusPolicy {
+	if policy, ok := gvkkey.Lookup(p.overrides, gvk); ok {
+		return policy
+	}
+	return p.fallback
+}
+
+// staleStatus returns a message explaining why the status of the supplied
+// composed resource doesn't reflect its latest spec yet, either because its
+// status or any of its status conditions were observed at an older
+// generation. It returns false if the status is current, or if the resource doesn't report which generation
+// its status reflects.
+func staleStatus(u *composed.Unstructured) (string, bool) {
+	var generation int64
+	if err := u.GetValueInto("metadata.generation", &generation); err != nil || generation == 0 {
+		return "", false
+	}
+
+	var observed int64
+	if err := u.GetValueInto("status.observedGeneration", &observed); err == nil && observed < generation {
+		return fmt.Sprintf("status is stale: observed generation %d is behind generation %d", observed, generation), true
+	}
+
+	// Crossplane-style conditions record the generation they were observed
+	// at. Any of them may be stale, not just Ready.
+	var conditions []xpv2.Condition
+	_ = u.GetValueInto("status.conditions", &conditions)
+	for _, c := range conditions {
+		if c.ObservedGeneration != 0 && c.ObservedGeneration < generation {
+			return fmt.Sprintf("status is stale: %s condition observed generation %d is behind generation %d", c.Type, c.ObservedGeneration, generation), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/function-sdk-go/resource/composed"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+
+	"github.com/crossplane/function-auto-ready/healthchecks"
+)
+
+// typeResponsive is the type of the condition a composite resource uses to
+// report whether it's responding to changes to its composed resources.
+const typeResponsive xpv2.ConditionType = "Responsive"
+
+// isCompositeResource returns true if the supplied composed resource is itself
+// a composite resource, i.e. a nested XR. Crossplane v2 composite resources
+// have spec.crossplane.compositionRef, while legacy ones have
+// spec.compositionRef.
+func isCompositeResource(u *composed.Unstructured) bool {
+	for _, path := range []string{"spec.crossplane.compositionRef", "spec.compositionRef"} {
+		if v, err := u.GetValue(path); err == nil && v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// syncedHealth returns the health of a composed resource with a True Ready
+// condition when its Synced condition must be True too. A nested XR's
+// Responsive condition must be True too. Resources without these conditions,
+// which aren't Crossplane resources, are healthy.
+func syncedHealth(u *composed.Unstructured, ready xpv2.Condition) healthchecks.HealthStatus {
+	types := []xpv2.ConditionType{xpv2.TypeSynced}
+	if isCompositeResource(u) {
+		types = append(types, typeResponsive)
+	}
+
+	for _, t := range types {
+		c := u.GetCondition(t)
+		if c.Status == corev1.ConditionTrue || (c.Status == corev1.ConditionUnknown && c.Reason == "") {
+			// The condition is True, or the resource doesn't have it.
+			continue
+		}
+		msg := fmt.Sprintf("Ready, but %s condition is %s", t, c.Status)
+		if c.Reason != "" {
+			msg = fmt.Sprintf("%s: %s", msg, c.Reason)
+		}
+		if c.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, c.Message)
+		}
+		if c.Status == corev1.ConditionFalse && c.Reason == xpv2.ReasonReconcileError {
+			// The resource's latest spec couldn't be applied.
+			return healthchecks.Degraded(msg)
+		}
+		return healthchecks.Progressing(msg)
+	}
+
+	return healthchecks.Healthy(ready.Message)
+}